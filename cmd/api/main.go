@@ -6,6 +6,7 @@ import (
 	"inventory_app_backend/internal/config"
 	"inventory_app_backend/internal/handlers"
 	"inventory_app_backend/internal/routes"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/pkg/database"
 	"inventory_app_backend/pkg/firebase"
 	"log"
@@ -34,6 +35,9 @@ func main() {
 	}
 	log.Println("Storage berhasil diinisialisasi")
 
+	// Hapus file gambar lama yang masa tenggangnya sudah lewat
+	go services.RunPendingDeletions(db, time.Hour)
+
 	authHandler := &handlers.AuthHandler{DB: db}
 	itemHandler := &handlers.ItemHandler{DB: db}
 	itemTypeHandler := &handlers.ItemTypeHandler{DB: db}
@@ -41,6 +45,12 @@ func main() {
 	transactionHandler := &handlers.TransactionHandler{DB: db}
 	reportHandler := &handlers.ReportHandler{DB: db}
 	summaryHandler := &handlers.SummaryHandler{DB: db}
+	inspectionHandler := &handlers.InspectionHandler{DB: db}
+	periodHandler := &handlers.PeriodHandler{DB: db}
+	auditLogHandler := &handlers.AuditLogHandler{DB: db}
+	reconciliationHandler := &handlers.ReconciliationHandler{DB: db}
+	roleHandler := &handlers.RoleHandler{DB: db}
+	apiKeyHandler := &handlers.APIKeyHandler{DB: db}
 
 	// Setup router
 	router := routes.SetupRouter(
@@ -51,6 +61,12 @@ func main() {
 		transactionHandler,
 		reportHandler,
 		summaryHandler,
+		inspectionHandler,
+		periodHandler,
+		auditLogHandler,
+		reconciliationHandler,
+		roleHandler,
+		apiKeyHandler,
 	)
 	// Setup server
 	port := config.Get("APP_PORT")
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"inventory_app_backend/internal/config"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/pkg/database"
+	"inventory_app_backend/pkg/firebase"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	remove := flag.Bool("delete", false, "hapus file yang tidak dipakai barang atau lampiran mana pun dan tidak sedang menunggu jadwal penghapusan")
+	prefix := flag.String("prefix", "items/", "prefix objek di bucket yang diperiksa")
+	minAge := flag.Duration("min-age", 24*time.Hour, "lewati file yang lebih baru dari durasi ini")
+	revokePublic := flag.Bool("revoke-public", false, "cabut akses publik dari semua file dengan prefix, untuk file yang diupload sebelum storage private")
+	flag.Parse()
+
+	// Load konfigurasi
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Inisialisasi database
+	db, err := database.NewMySQLDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// inisialisasi firebase storage
+	if err := firebase.InitializeStorage(); err != nil {
+		log.Fatalf("Gagal inisialisasi storage: %v", err)
+	}
+
+	if *revokePublic {
+		revokePublicAccess(*prefix)
+		return
+	}
+
+	orphans, err := services.FindOrphanedImages(db, *prefix, *minAge)
+	if err != nil {
+		log.Fatalf("Gagal mencari file yang tidak dipakai: %v", err)
+	}
+
+	var totalSize int64
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tUKURAN\tDIBUAT\tSTATUS")
+	for _, object := range orphans {
+		status := "tidak dipakai"
+		if *remove {
+			if err := firebase.DeleteObject(object.Path); err != nil {
+				status = "gagal: " + err.Error()
+				failed++
+			} else {
+				status = "dihapus"
+			}
+		}
+		totalSize += object.Size
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n",
+			object.Path, object.Size, object.Created.Format(time.RFC3339), status)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d file tidak dipakai (%d byte) di bawah %s\n", len(orphans), totalSize, *prefix)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func revokePublicAccess(prefix string) {
+	objects, err := firebase.ListObjects(prefix)
+	if err != nil {
+		log.Fatalf("Gagal mengambil daftar file: %v", err)
+	}
+
+	failed := 0
+	for _, object := range objects {
+		if err := firebase.MakePrivate(object.Path); err != nil {
+			log.Printf("Gagal mencabut akses publik %s: %v", object.Path, err)
+			failed++
+		}
+	}
+
+	fmt.Printf("%d file di bawah %s diperiksa, %d gagal\n", len(objects), prefix, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"inventory_app_backend/internal/config"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/pkg/database"
+	"log"
+	"os"
+	"text/tabwriter"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "koreksi stok yang tidak sesuai dengan riwayat transaksi")
+	username := flag.String("user", "", "username admin yang dicatat di audit log (wajib jika -fix)")
+	flag.Parse()
+
+	// Load konfigurasi
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Inisialisasi database
+	db, err := database.NewMySQLDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	var userID string
+	if *fix {
+		if *username == "" {
+			log.Fatal("Flag -user wajib diisi jika menggunakan -fix")
+		}
+		var user models.User
+		if err := db.Where("username = ?", *username).First(&user).Error; err != nil {
+			log.Fatalf("User %s tidak ditemukan: %v", *username, err)
+		}
+		userID = user.UserID
+	}
+
+	result, err := services.ReconcileStock(db, *fix, userID)
+	if err != nil {
+		log.Fatalf("Rekonsiliasi gagal: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ITEM ID\tNAMA BARANG\tSTOK\tSEHARUSNYA\tKARANTINA\tSEHARUSNYA\tSTATUS")
+	for _, drift := range result.Drifts {
+		status := "selisih"
+		if drift.Fixed {
+			status = "dikoreksi"
+		} else if drift.Skipped != "" {
+			status = drift.Skipped
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\n",
+			drift.ItemID, drift.ItemName,
+			drift.RecordedStock, drift.ExpectedStock,
+			drift.RecordedQuarantine, drift.ExpectedQuarantine,
+			status)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d item diperiksa, %d selisih ditemukan\n", result.CheckedItems, len(result.Drifts))
+
+	// Exit code 1 jika masih ada selisih yang belum dikoreksi
+	for _, drift := range result.Drifts {
+		if !drift.Fixed {
+			os.Exit(1)
+		}
+	}
+}
@@ -5,13 +5,18 @@ go 1.24.2
 require (
 	cloud.google.com/go/storage v1.51.0
 	firebase.google.com/go/v4 v4.15.2
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/boombuler/barcode v1.0.2
 	github.com/gin-gonic/gin v1.10.0
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-playground/validator/v10 v10.26.0
 	github.com/golang-jwt/jwt/v5 v5.2.2
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/xuri/excelize/v2 v2.9.0
 	golang.org/x/crypto v0.37.0
+	golang.org/x/image v0.18.0
+	golang.org/x/oauth2 v0.28.0
 	google.golang.org/api v0.228.0
 	gorm.io/driver/mysql v1.5.7
 	gorm.io/gorm v1.25.12
@@ -81,7 +86,6 @@ require (
 	go.opentelemetry.io/otel/trace v1.34.0 // indirect
 	golang.org/x/arch v0.16.0 // indirect
 	golang.org/x/net v0.39.0 // indirect
-	golang.org/x/oauth2 v0.28.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
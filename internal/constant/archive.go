@@ -0,0 +1,8 @@
+package constants
+
+// Filter data arsip pada daftar barang, jenis barang dan satuan
+const (
+	ArchivedFilterActive   = "active"
+	ArchivedFilterArchived = "archived"
+	ArchivedFilterAll      = "all"
+)
@@ -0,0 +1,5 @@
+package constants
+
+// MaxTransactionAttachments adalah jumlah maksimal lampiran untuk satu
+// transaksi atau satu dokumen
+const MaxTransactionAttachments = 20
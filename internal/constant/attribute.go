@@ -0,0 +1,8 @@
+package constants
+
+const (
+	AttributeTypeText   = "text"
+	AttributeTypeNumber = "number"
+	AttributeTypeEnum   = "enum"
+	AttributeTypeDate   = "date"
+)
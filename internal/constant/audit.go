@@ -0,0 +1,48 @@
+package constants
+
+const (
+	AuditActionPeriodClose       = "period.close"
+	AuditActionPeriodReopen      = "period.reopen"
+	AuditActionStockReconcile    = "stock.reconcile"
+	AuditActionTransactionImport = "transaction.import"
+	AuditActionItemImport        = "item.import"
+	AuditActionBOMUpdate         = "item.bom_update"
+	AuditActionKitAssemble       = "kit.assemble"
+	AuditActionKitDisassemble    = "kit.disassemble"
+	AuditActionItemArchive       = "item.archive"
+	AuditActionItemRestore       = "item.restore"
+	AuditActionItemTypeArchive   = "item_type.archive"
+	AuditActionItemTypeRestore   = "item_type.restore"
+	AuditActionUnitArchive       = "unit.archive"
+	AuditActionUnitRestore       = "unit.restore"
+	AuditActionItemMerge         = "item.merge"
+	AuditActionAttributesUpdate  = "item_type.attributes_update"
+	AuditActionAttachmentDelete  = "transaction.attachment_delete"
+	AuditActionRoleCreate        = "role.create"
+	AuditActionRoleUpdate        = "role.update"
+	AuditActionRoleDelete        = "role.delete"
+	AuditActionAPIKeyCreate      = "api_key.create"
+	AuditActionAPIKeyRevoke      = "api_key.revoke"
+	AuditActionUserUnlock        = "user.unlock"
+	AuditActionUserDeactivate    = "user.deactivate"
+	AuditActionUserActivate      = "user.activate"
+	AuditActionTwoFactorEnable   = "user.2fa_enable"
+	AuditActionTwoFactorDisable  = "user.2fa_disable"
+	AuditActionTwoFactorReset    = "user.2fa_reset"
+	AuditActionRecoveryCodesNew  = "user.recovery_codes_regenerate"
+	AuditActionUserSSOProvision  = "user.sso_provision"
+	AuditActionUserSSORoleSync   = "user.sso_role_sync"
+)
+
+const (
+	AuditEntityPeriod     = "closed_period"
+	AuditEntityItem       = "item"
+	AuditEntityImport     = "import_batch"
+	AuditEntityKit        = "kit_operation"
+	AuditEntityItemType   = "item_type"
+	AuditEntityUnit       = "unit"
+	AuditEntityAttachment = "transaction_attachment"
+	AuditEntityRole       = "role"
+	AuditEntityAPIKey     = "api_key"
+	AuditEntityUser       = "user"
+)
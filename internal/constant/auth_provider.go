@@ -0,0 +1,8 @@
+package constants
+
+// Sumber autentikasi user. User lokal login dengan password, user OIDC
+// login lewat identity provider perusahaan.
+const (
+	AuthProviderLocal = "local"
+	AuthProviderOIDC  = "oidc"
+)
@@ -0,0 +1,15 @@
+package constants
+
+const (
+	SymbologyEAN13   = "ean13"
+	SymbologyCode128 = "code128"
+	SymbologyCustom  = "custom"
+)
+
+// Jenis kode dan format output label
+const (
+	LabelSymbologyCode128 = "code128"
+	LabelSymbologyQR      = "qr"
+	LabelFormatPDF        = "pdf"
+	LabelFormatZPL        = "zpl"
+)
@@ -0,0 +1,27 @@
+package constants
+
+// Kode error spesifik yang dikirim di field error.details.error_code
+const (
+	ErrCodePeriodClosed             = "PERIOD_CLOSED"
+	ErrCodeNegativeStock            = "NEGATIVE_STOCK_AT_DATE"
+	ErrCodeLoginLocked              = "LOGIN_LOCKED"
+	ErrCodePasswordChangeRequired   = "PASSWORD_CHANGE_REQUIRED"
+	ErrCodeTwoFactorSetupRequired   = "TWO_FACTOR_SETUP_REQUIRED"
+	ErrCodeReconciliationAdjustment = "RECONCILIATION_ADJUSTMENT"
+)
+
+// Kode error penolakan upload gambar
+const (
+	ErrCodeImageEmpty           = "IMAGE_EMPTY"
+	ErrCodeImageTooLarge        = "IMAGE_TOO_LARGE"
+	ErrCodeImageUnsupportedType = "IMAGE_UNSUPPORTED_TYPE"
+	ErrCodeImageCorrupt         = "IMAGE_CORRUPT"
+	ErrCodeImageDimensions      = "IMAGE_DIMENSIONS_TOO_LARGE"
+)
+
+// Kode error penolakan upload lampiran transaksi
+const (
+	ErrCodeAttachmentEmpty           = "ATTACHMENT_EMPTY"
+	ErrCodeAttachmentTooLarge        = "ATTACHMENT_TOO_LARGE"
+	ErrCodeAttachmentUnsupportedType = "ATTACHMENT_UNSUPPORTED_TYPE"
+)
@@ -0,0 +1,4 @@
+package constants
+
+// MaxItemImages adalah jumlah maksimal gambar di galeri satu barang
+const MaxItemImages = 10
@@ -0,0 +1,12 @@
+package constants
+
+const (
+	InspectionStatusNone      = "none"
+	InspectionStatusPending   = "pending"
+	InspectionStatusInspected = "inspected"
+)
+
+const (
+	DispositionReturn = "return"
+	DispositionScrap  = "scrap"
+)
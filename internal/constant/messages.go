@@ -4,31 +4,119 @@ package constants
 // AUTHENTICATION MESSAGES
 // ========================
 const (
-	MsgLoginSuccess        = "Login berhasil"
-	MsgInvalidCredentials  = "Invalid credentials"
-	MsgUsernameNotFound    = "Username tidak ditemukan"
-	MsgPasswordWrong       = "Password salah"
-	MsgTokenGenerationFail = "Gagal membuat token"
-	MsgTokenInvalid        = "Token tidak valid"
-	MsgAuthHeaderRequired  = "Authorization header wajib diisi"
+	MsgLoginSuccess           = "Login berhasil"
+	MsgInvalidCredentials     = "Invalid credentials"
+	MsgUsernameNotFound       = "Username tidak ditemukan"
+	MsgPasswordWrong          = "Password salah"
+	MsgTokenGenerationFail    = "Gagal membuat token"
+	MsgTokenInvalid           = "Token tidak valid"
+	MsgAuthHeaderRequired     = "Authorization header wajib diisi"
+	MsgLoginLocked            = "Terlalu banyak percobaan login gagal, coba lagi dalam %d menit"
+	MsgUserInactive           = "Akun tidak aktif"
+	MsgPasswordChangeRequired = "Password harus diganti sebelum melanjutkan"
+	MsgAPIKeyInvalid          = "API key tidak valid"
+	MsgAPIKeyIPNotAllowed     = "IP tidak diizinkan untuk API key ini"
+	MsgTwoFactorChallenge     = "Masukkan kode autentikasi dua faktor"
+	MsgTwoFactorInvalidCode   = "Kode autentikasi dua faktor salah"
+	MsgTwoFactorSetupRequired = "Role Anda mewajibkan autentikasi dua faktor, aktifkan terlebih dahulu"
 )
 
 // ========================
 // USER MESSAGES
 // ========================
 const (
-	MsgUserCreatedSuccess  = "User berhasil dibuat"
-	MsgUserUpdateSuccess   = "User berhasil diperbarui"
-	MsgUsernameExists      = "Username sudah digunakan"
-	MsgUsernameRegistered  = "Username sudah terdaftar"
-	MsgInvalidRole         = "Role tidak valid"
-	MsgInvalidRoleValue    = "Role harus salah satu dari: admin, warehouse_admin, warehouse_manager"
-	MsgUserNotFound        = "User tidak ditemukan"
-	MsgPasswordEncryptFail = "Gagal mengenkripsi password"
-	MsgUserSaveFail        = "Gagal menyimpan user"
-	MsgUsersFetchSuccess   = "Daftar user berhasil didapatkan"
-	MsgUserFetchSuccess    = "Data user berhasil didapatkan"
-	MsgInvalidSession      = "Sesi tidak valid"
+	MsgUserCreatedSuccess   = "User berhasil dibuat"
+	MsgUserUpdateSuccess    = "User berhasil diperbarui"
+	MsgUsernameExists       = "Username sudah digunakan"
+	MsgUsernameRegistered   = "Username sudah terdaftar"
+	MsgInvalidRole          = "Role tidak valid"
+	MsgInvalidRoleValue     = "Role tidak terdaftar"
+	MsgUserNotFound         = "User tidak ditemukan"
+	MsgPasswordEncryptFail  = "Gagal mengenkripsi password"
+	MsgUserSaveFail         = "Gagal menyimpan user"
+	MsgUsersFetchSuccess    = "Daftar user berhasil didapatkan"
+	MsgUserFetchSuccess     = "Data user berhasil didapatkan"
+	MsgInvalidSession       = "Sesi tidak valid"
+	MsgUserUnlockedSuccess  = "Kunci login user berhasil dibuka"
+	MsgUserDeactivated      = "User berhasil dinonaktifkan"
+	MsgUserActivated        = "User berhasil diaktifkan"
+	MsgUserDeactivateSelf   = "Tidak dapat menonaktifkan akun sendiri"
+	MsgProfileFetchSuccess  = "Profil berhasil didapatkan"
+	MsgProfileUpdated       = "Profil berhasil diperbarui"
+	MsgPasswordChanged      = "Password berhasil diganti"
+	MsgPasswordCurrentWrong = "Password saat ini salah"
+	MsgPasswordUnchanged    = "Password baru harus berbeda dari password saat ini"
+)
+
+// ========================
+// TWO-FACTOR AUTH MESSAGES
+// ========================
+const (
+	MsgTwoFactorStatusFetched    = "Status 2FA berhasil didapatkan"
+	MsgTwoFactorSetupSuccess     = "Pindai QR code lalu verifikasi kode untuk mengaktifkan 2FA"
+	MsgTwoFactorEnabled          = "Autentikasi dua faktor berhasil diaktifkan"
+	MsgTwoFactorDisabled         = "Autentikasi dua faktor berhasil dinonaktifkan"
+	MsgTwoFactorReset            = "Autentikasi dua faktor user berhasil direset"
+	MsgTwoFactorAlreadyEnabled   = "Autentikasi dua faktor sudah aktif"
+	MsgTwoFactorNotEnabled       = "Autentikasi dua faktor belum aktif"
+	MsgTwoFactorNotSetUp         = "Lakukan setup 2FA terlebih dahulu"
+	MsgTwoFactorRequiredByRole   = "Role Anda mewajibkan autentikasi dua faktor"
+	MsgTwoFactorSaveFailed       = "Gagal menyimpan pengaturan 2FA"
+	MsgRecoveryCodesRegenerated  = "Kode pemulihan baru berhasil dibuat"
+	MsgTwoFactorChallengeInvalid = "Sesi login 2FA tidak valid atau sudah kedaluwarsa"
+)
+
+// ========================
+// SSO MESSAGES
+// ========================
+const (
+	MsgOIDCDisabled          = "Login SSO tidak diaktifkan"
+	MsgOIDCProviderError     = "Gagal menghubungi identity provider"
+	MsgOIDCLoginStarted      = "Arahkan user ke URL login SSO"
+	MsgOIDCStateInvalid      = "Sesi login SSO tidak valid atau sudah kedaluwarsa"
+	MsgOIDCLoginFailed       = "Login SSO gagal"
+	MsgOIDCNoRole            = "Akun SSO tidak termasuk grup yang diizinkan"
+	MsgOIDCRoleInvalid       = "Role hasil pemetaan SSO tidak terdaftar"
+	MsgOIDCUsernameTaken     = "Username sudah dipakai akun lokal"
+	MsgOIDCUsernameAbsent    = "Identity provider tidak mengirim username atau email"
+	MsgPasswordManagedBySSO  = "Password akun SSO diatur di identity provider"
+	MsgTwoFactorManagedBySSO = "Autentikasi dua faktor akun SSO diatur di identity provider"
+)
+
+// ========================
+// ROLE MESSAGES
+// ========================
+const (
+	MsgRolesFetchSuccess       = "Daftar role berhasil didapatkan"
+	MsgPermissionsFetchSuccess = "Daftar izin berhasil didapatkan"
+	MsgRoleCreatedSuccess      = "Role berhasil dibuat"
+	MsgRoleUpdatedSuccess      = "Role berhasil diperbarui"
+	MsgRoleDeletedSuccess      = "Role berhasil dihapus"
+	MsgRoleSaveFailed          = "Gagal menyimpan role"
+	MsgRoleNotFound            = "Role tidak ditemukan"
+	MsgRoleExists              = "Role sudah ada"
+	MsgRoleNameInvalid         = "Nama role hanya boleh berisi huruf kecil, angka dan garis bawah, diawali huruf"
+	MsgRolePermissionUnknown   = "Izin %s tidak terdaftar"
+	MsgRolePermissionAdminOnly = "Izin %s hanya untuk role admin"
+	MsgRoleAdminLocked         = "Izin role admin tidak dapat diubah"
+	MsgRoleSystemDelete        = "Role bawaan sistem tidak dapat dihapus"
+	MsgRoleInUse               = "Role masih dipakai oleh %d user"
+)
+
+// ========================
+// API KEY MESSAGES
+// ========================
+const (
+	MsgAPIKeysFetchSuccess     = "Daftar API key berhasil didapatkan"
+	MsgAPIKeyCreatedSuccess    = "API key berhasil dibuat, simpan kunci ini karena tidak akan ditampilkan lagi"
+	MsgAPIKeyRevokedSuccess    = "API key berhasil dicabut"
+	MsgAPIKeySaveFailed        = "Gagal menyimpan API key"
+	MsgAPIKeyNotFound          = "API key tidak ditemukan"
+	MsgAPIKeyAlreadyRevoked    = "API key sudah dicabut"
+	MsgAPIKeyExpiryInvalid     = "Waktu kedaluwarsa harus di masa depan"
+	MsgAPIKeyIPInvalid         = "%s bukan alamat IP atau CIDR yang valid"
+	MsgAPIKeyAccountNotService = "Username sudah dipakai oleh user biasa"
+	MsgRoleServiceAccountOnly  = "Role service_account hanya untuk akun API key"
 )
 
 // ========================
@@ -60,25 +148,99 @@ const (
 // ITEM MESSAGES
 // ========================
 const (
-	MsgItemCreatedSuccess   = "Barang berhasil dibuat"
-	MsgItemCreatedFailed    = "Gagal menyimpan barang"
-	MsgInvalidItemType      = "Jenis barang tidak valid"
-	MsgInvalidUnit          = "Satuan barang tidak valid"
-	MsgItemNotFound         = "Barang tidak ditemukan"
-	MsgImageUploadFailed    = "Gagal mengupload gambar"
-	MsgInvalidImageFormat   = "Format gambar tidak valid"
-	MsgImageTooLarge        = "Ukuran gambar terlalu besar"
-	MsgImageAllowedFormat   = "Hanya menerima format JPG, JPEG, atau PNG"
-	MsgImageAllowedSizes    = "Maksimal ukuran gambar 5MB"
-	MsgItemUpdatedSuccess   = "Item berhasil diperbarui"
-	MsgItemUpdatedFailed    = "Gagal memperbarui item"
-	MsgGetUpdatedItemFailed = "Gagal memuat data terupdate"
-	MsgItemDeletedSuccess   = "Item berhasil dihapus"
-	MsgItemDeleteFailed     = "Gagal menghapus item"
-	MsgItemsFetchSuccess    = "Daftar item berhasil didapatkan"
-	MsgItemFetchSuccess     = "Detail item berhasil didapatkan"
-	MsgItemInUse            = "Item tidak dapat dihapus"
-	MsgItemInUseDetail      = "Item sedang digunakan dalam %d transaksi"
+	MsgItemCreatedSuccess    = "Barang berhasil dibuat"
+	MsgItemCreatedFailed     = "Gagal menyimpan barang"
+	MsgInvalidItemType       = "Jenis barang tidak valid"
+	MsgInvalidUnit           = "Satuan barang tidak valid"
+	MsgItemNotFound          = "Barang tidak ditemukan"
+	MsgImageUploadFailed     = "Gagal mengupload gambar"
+	MsgInvalidImageFormat    = "Format gambar tidak valid"
+	MsgImageTooLarge         = "Ukuran gambar terlalu besar"
+	MsgImageAllowedFormat    = "Hanya menerima format JPG, JPEG, atau PNG"
+	MsgImageAllowedSizes     = "Maksimal ukuran gambar 5MB"
+	MsgImageDecodeFailed     = "File gambar rusak atau tidak dapat dibaca"
+	MsgImageEmpty            = "File gambar kosong"
+	MsgImageContentInvalid   = "Isi file bukan gambar JPG atau PNG"
+	MsgImageDimensionsLimit  = "Resolusi gambar maksimal 40 megapiksel"
+	MsgItemUpdatedSuccess    = "Item berhasil diperbarui"
+	MsgItemUpdatedFailed     = "Gagal memperbarui item"
+	MsgGetUpdatedItemFailed  = "Gagal memuat data terupdate"
+	MsgItemDeletedSuccess    = "Item berhasil dihapus"
+	MsgItemDeleteFailed      = "Gagal menghapus item"
+	MsgItemsFetchSuccess     = "Daftar item berhasil didapatkan"
+	MsgItemFetchSuccess      = "Detail item berhasil didapatkan"
+	MsgItemInUse             = "Item tidak dapat dihapus"
+	MsgItemInUseDetail       = "Item sedang digunakan dalam %d transaksi"
+	MsgInvalidSKU            = "SKU hanya boleh berisi huruf, angka, titik, garis bawah atau strip (maksimal 64 karakter)"
+	MsgSKUExists             = "SKU sudah digunakan barang lain"
+	MsgBarcodeAddedSuccess   = "Barcode berhasil ditambahkan"
+	MsgBarcodeAddFailed      = "Gagal menambahkan barcode"
+	MsgBarcodeDeletedSuccess = "Barcode berhasil dihapus"
+	MsgBarcodeDeleteFailed   = "Gagal menghapus barcode"
+	MsgBarcodeNotFound       = "Barcode tidak ditemukan"
+	MsgBarcodeExists         = "Barcode sudah terdaftar"
+	MsgBarcodeExistsDetail   = "Barcode sudah digunakan oleh barang %s"
+	MsgBarcodeUsedAsSKU      = "Barcode sudah digunakan sebagai SKU barang %s"
+	MsgSKUUsedAsBarcode      = "SKU sudah digunakan sebagai barcode barang %s"
+	MsgInvalidBarcode        = "Format barcode tidak valid"
+	MsgInvalidBarcodeEAN13   = "EAN-13 harus 13 digit dengan digit pemeriksa yang benar"
+	MsgInvalidBarcode128     = "Code128 hanya menerima karakter ASCII yang dapat dicetak (maksimal 48 karakter)"
+	MsgInvalidBarcodeCustom  = "Barcode custom hanya boleh berisi huruf, angka, titik, garis bawah, strip atau garis miring (maksimal 64 karakter)"
+	MsgItemLookupNotFound    = "Tidak ada barang dengan kode tersebut"
+	MsgLabelItemsNotFound    = "Barang tidak ditemukan: %s"
+	MsgLabelGenerateFailed   = "Gagal membuat label"
+	MsgLabelLayoutTooSmall   = "Ukuran label terlalu kecil untuk tata letak yang dipilih"
+	MsgLabelFilename         = "label_barang_"
+	MsgItemArchivedSuccess   = "Item berhasil diarsipkan"
+	MsgItemArchiveFailed     = "Gagal mengarsipkan item"
+	MsgItemRestoredSuccess   = "Item berhasil dipulihkan"
+	MsgItemRestoreFailed     = "Gagal memulihkan item"
+	MsgItemArchived          = "Item sudah diarsipkan"
+	MsgItemArchivedDetail    = "Item %s sudah diarsipkan, pulihkan terlebih dahulu untuk mencatat pergerakan stok"
+	MsgItemNotArchived       = "Item tidak sedang diarsipkan"
+	MsgItemMergePreview      = "Pratinjau penggabungan item berhasil dibuat"
+	MsgItemMergedSuccess     = "Item berhasil digabungkan"
+	MsgItemMergeFailed       = "Gagal menggabungkan item"
+	MsgItemMergeInvalid      = "Item tidak dapat digabungkan"
+	MsgItemMergeSelf         = "Item tujuan tidak boleh menjadi item sumber"
+	MsgItemMergeUnitMismatch = "Satuan %s berbeda dengan satuan item tujuan (%s)"
+	MsgItemMergeClosedPeriod = "%d transaksi item sumber berada di periode tertutup, buka kembali periode tersebut terlebih dahulu"
+)
+
+// ========================
+// ITEM IMAGE MESSAGES
+// ========================
+const (
+	MsgItemImagesFetchSuccess     = "Daftar gambar barang berhasil didapatkan"
+	MsgItemImageAddedSuccess      = "Gambar barang berhasil ditambahkan"
+	MsgItemImageDeletedSuccess    = "Gambar barang berhasil dihapus"
+	MsgItemImagePrimarySuccess    = "Gambar utama barang berhasil diubah"
+	MsgItemImagesReorderedSuccess = "Urutan gambar barang berhasil diubah"
+	MsgItemImageFailed            = "Gagal menyimpan gambar barang"
+	MsgItemImageNotFound          = "Gambar barang tidak ditemukan"
+	MsgItemImageLimit             = "Maksimal %d gambar per barang"
+	MsgItemImageOrderInvalid      = "Urutan harus memuat seluruh gambar barang tepat satu kali"
+)
+
+// ========================
+// KIT / BILL OF MATERIALS MESSAGES
+// ========================
+const (
+	MsgBOMFetchSuccess           = "Daftar komponen kit berhasil didapatkan"
+	MsgBOMUpdatedSuccess         = "Komponen kit berhasil disimpan"
+	MsgBOMUpdateFailed           = "Gagal menyimpan komponen kit"
+	MsgBOMInvalid                = "Komponen kit tidak valid"
+	MsgBOMSelfReference          = "Kit tidak boleh menjadi komponen dirinya sendiri"
+	MsgBOMDuplicateComponent     = "Komponen yang sama sudah ada di baris %d"
+	MsgBOMCycle                  = "Komponen ini memuat kit %s sehingga membentuk siklus"
+	MsgKitNoBOM                  = "Barang belum memiliki daftar komponen"
+	MsgKitAssembledSuccess       = "Kit berhasil dirakit"
+	MsgKitDisassembledSuccess    = "Kit berhasil dibongkar"
+	MsgKitOperationFailed        = "Gagal memproses perakitan kit"
+	MsgKitInsufficientComponents = "Stok tidak mencukupi untuk perakitan kit"
+	MsgKitAssemblyDescription    = "Perakitan kit %s x%d"
+	MsgKitDisassemblyDescription = "Pembongkaran kit %s x%d"
+	MsgTransactionLinkedKit      = "Transaksi bagian dari perakitan kit dan tidak dapat diubah atau dihapus, gunakan operasi bongkar atau rakit kembali"
 )
 
 // ========================
@@ -98,6 +260,41 @@ const (
 	MsgItemTypeDeleteFailed   = "Gagal menghapus jenis barang"
 	MsgItemTypeInUse          = "Jenis barang tidak dapat dihapus"
 	MsgItemTypeInUseDetail    = "Jenis barang sedang digunakan oleh  %d barang"
+	MsgStockPolicyUpdated     = "Kebijakan stok jenis barang berhasil diperbarui"
+	MsgItemTypeArchivedOK     = "Jenis barang berhasil diarsipkan"
+	MsgItemTypeRestoredOK     = "Jenis barang berhasil dipulihkan"
+	MsgItemTypeArchived       = "Jenis barang sudah diarsipkan"
+	MsgItemTypeNotArchived    = "Jenis barang tidak sedang diarsipkan"
+	MsgItemTypeExistsArchived = "Nama jenis barang sudah terdaftar di arsip, pulihkan jenis barang tersebut"
+	MsgItemTypeParentNotFound = "Jenis barang induk tidak ditemukan"
+	MsgItemTypeParentArchived = "Jenis barang induk sudah diarsipkan"
+	MsgItemTypeParentCycle    = "Jenis barang induk tidak boleh jenis barang itu sendiri atau turunannya"
+	MsgItemTypeHasChildren    = "Jenis barang masih memiliki %d sub-jenis aktif, arsipkan sub-jenis terlebih dahulu"
+	MsgItemTypeTreeSuccess    = "Pohon jenis barang berhasil didapatkan"
+)
+
+// ========================
+// ITEM ATTRIBUTE MESSAGES
+// ========================
+const (
+	MsgAttributesFetchSuccess   = "Daftar atribut jenis barang berhasil didapatkan"
+	MsgAttributesUpdatedSuccess = "Atribut jenis barang berhasil disimpan"
+	MsgAttributesUpdateFailed   = "Gagal menyimpan atribut jenis barang"
+	MsgAttributesInvalid        = "Atribut jenis barang tidak valid"
+	MsgAttributeNameInvalid     = "Nama atribut hanya boleh berisi huruf kecil, angka dan garis bawah, diawali huruf"
+	MsgAttributeNameDuplicate   = "Nama atribut sudah dipakai di baris %d"
+	MsgAttributeOptionsRequired = "Atribut enum wajib memiliki minimal satu opsi"
+	MsgAttributeOptionDuplicate = "Opsi %s duplikat"
+	MsgAttributeTypeInUse       = "Tipe data tidak dapat diubah karena %d barang sudah memiliki nilai atribut ini"
+	MsgAttributeOptionInUse     = "Opsi %s masih dipakai oleh %d barang"
+	MsgItemAttributesInvalid    = "Atribut barang tidak valid"
+	MsgItemAttributesMalformed  = "Atribut harus berupa objek JSON berisi teks atau angka, contoh {\"warna\": \"merah\"}"
+	MsgItemAttributeUnknown     = "Atribut tidak terdaftar pada jenis barang ini"
+	MsgItemAttributeRequired    = "Atribut wajib diisi"
+	MsgItemAttributeNumber      = "Nilai harus berupa angka"
+	MsgItemAttributeDate        = "Tanggal harus berformat YYYY-MM-DD"
+	MsgItemAttributeEnum        = "Nilai harus salah satu dari: %s"
+	MsgItemAttributeTooLong     = "Nilai maksimal 255 karakter"
 )
 
 // ========================
@@ -117,6 +314,11 @@ const (
 	MsgUnitsFetchSuccess  = "Daftar satuan berhasil didapatkan"
 	MsgUnitFetchSuccess   = "Detail satuan berhasil didapatkan"
 	MsgUnitInUseDetail    = "Satuan barang sedang digunakan oleh  %d barang"
+	MsgUnitArchivedOK     = "Satuan berhasil diarsipkan"
+	MsgUnitRestoredOK     = "Satuan berhasil dipulihkan"
+	MsgUnitArchived       = "Satuan sudah diarsipkan"
+	MsgUnitNotArchived    = "Satuan tidak sedang diarsipkan"
+	MsgUnitExistsArchived = "Nama satuan sudah terdaftar di arsip, pulihkan satuan tersebut"
 )
 
 // ========================
@@ -132,6 +334,115 @@ const (
 	MsgTransactionDeleteFailed   = "Gagal menghapus transaksi"
 	MsgTransactionNotFound       = "Transaksi tidak ditemukan"
 	MsgTransactionAdjustStock    = "Stok telah disesuaikan ke 0 karena penghapusan transaksi menyebabkan stok negatif"
+	MsgInsufficientStockAtDate   = "Stok tidak mencukupi pada tanggal transaksi atau setelahnya"
+	MsgNegativeStockDetail       = "Stok akan menjadi %d pada tanggal %s"
+	MsgStockCheckFailed          = "Gagal memeriksa saldo stok"
+	MsgTransactionUpdatedSuccess = "Transaksi berhasil diperbarui"
+	MsgTransactionUpdateFailed   = "Gagal memperbarui transaksi"
+	MsgTransactionInspected      = "Transaksi yang sudah diinspeksi tidak dapat diubah jumlah atau barangnya"
+	MsgTransactionChanged        = "Transaksi diubah oleh proses lain, muat ulang lalu coba lagi"
+	MsgRevisionsFetchSuccess     = "Riwayat perubahan transaksi berhasil didapatkan"
+)
+
+// ========================
+// TRANSACTION ATTACHMENT MESSAGES
+// ========================
+const (
+	MsgAttachmentsFetchSuccess   = "Daftar lampiran berhasil didapatkan"
+	MsgAttachmentsAddedSuccess   = "Lampiran berhasil ditambahkan"
+	MsgAttachmentDeletedSuccess  = "Lampiran berhasil dihapus"
+	MsgAttachmentFailed          = "Gagal menyimpan lampiran"
+	MsgAttachmentInvalid         = "File lampiran tidak valid"
+	MsgAttachmentNotFound        = "Lampiran tidak ditemukan"
+	MsgAttachmentDocumentMissing = "Dokumen tidak ditemukan"
+	MsgAttachmentLimit           = "Maksimal %d lampiran per transaksi atau dokumen"
+	MsgAttachmentTypeInvalid     = "Hanya menerima file PDF, JPEG atau PNG"
+	MsgAttachmentAllowedSize     = "Maksimal ukuran file 10MB"
+)
+
+// ========================
+// IMPORT MESSAGES
+// ========================
+const (
+	MsgImportValidated        = "Validasi file impor selesai"
+	MsgImportCommitted        = "Data impor berhasil disimpan"
+	MsgImportHasErrors        = "File impor mengandung kesalahan, tidak ada data yang disimpan"
+	MsgImportFailed           = "Gagal menyimpan data impor"
+	MsgImportStale            = "Stok atau periode berubah sejak validasi, tidak ada data yang disimpan"
+	MsgImportFileRequired     = "File impor wajib diunggah"
+	MsgImportInvalidFile      = "File impor tidak valid"
+	MsgImportAllowedFormat    = "Hanya menerima format XLSX atau CSV"
+	MsgImportAllowedSize      = "Maksimal ukuran file 5MB"
+	MsgImportEmpty            = "File impor tidak memiliki data"
+	MsgImportTooManyRows      = "Maksimal %d baris per impor"
+	MsgImportMissingColumn    = "Kolom %s wajib ada di header"
+	MsgImportItemNotFound     = "Barang tidak ditemukan"
+	MsgImportItemAmbiguous    = "Nama barang cocok dengan lebih dari satu barang, gunakan ID"
+	MsgImportInvalidType      = "Tipe transaksi harus in atau out"
+	MsgImportInvalidQuantity  = "Jumlah harus bilangan bulat minimal 1"
+	MsgImportInvalidDate      = "Tanggal harus berformat YYYY-MM-DD"
+	MsgImportInsufficient     = "Stok tidak mencukupi, stok tersedia %d"
+	MsgImportTemplateFilename = "template_impor_transaksi"
+	MsgImportTypeNotFound     = "Jenis barang tidak ditemukan, aktifkan create_missing untuk membuat otomatis"
+	MsgImportUnitNotFound     = "Satuan tidak ditemukan, aktifkan create_missing untuk membuat otomatis"
+	MsgImportInvalidMinStock  = "Stok minimum harus bilangan bulat minimal 0"
+	MsgImportInvalidBoolean   = "Nilai harus true atau false"
+	MsgImportDuplicateRow     = "Barang yang sama sudah ada di baris %d"
+	MsgItemExportFilename     = "data_barang_"
+)
+
+// ========================
+// INSPECTION MESSAGES
+// ========================
+const (
+	MsgInspectionCreatedSuccess    = "Inspeksi berhasil disimpan"
+	MsgInspectionCreateFailed      = "Gagal menyimpan inspeksi"
+	MsgInspectionsFetchSuccess     = "Daftar inspeksi berhasil didapatkan"
+	MsgPendingInspectionsFetched   = "Daftar barang karantina berhasil didapatkan"
+	MsgInspectionNotPending        = "Transaksi tidak sedang menunggu inspeksi"
+	MsgInspectionQuantityMismatch  = "Jumlah inspeksi tidak sesuai"
+	MsgInspectionQuantityDetail    = "Jumlah diterima dan ditolak harus sama dengan %d"
+	MsgInspectionDispositionNeeded = "Disposisi wajib diisi (return atau scrap) jika ada barang ditolak"
+)
+
+// ========================
+// ACCOUNTING PERIOD MESSAGES
+// ========================
+const (
+	MsgPeriodClosedSuccess   = "Periode berhasil ditutup"
+	MsgPeriodCloseFailed     = "Gagal menutup periode"
+	MsgPeriodReopenedSuccess = "Periode berhasil dibuka kembali"
+	MsgPeriodReopenFailed    = "Gagal membuka kembali periode"
+	MsgPeriodsFetchSuccess   = "Daftar periode berhasil didapatkan"
+	MsgPeriodNotFound        = "Periode tidak ditemukan"
+	MsgPeriodOverlap         = "Periode bertabrakan dengan periode tertutup lain"
+	MsgPeriodOverlapDetail   = "Tanggal %s sampai %s sudah ditutup"
+	MsgPeriodNotClosed       = "Periode tidak dalam status tertutup"
+	MsgPeriodLocked          = "Transaksi berada di periode yang sudah ditutup"
+	MsgPeriodLockedDetail    = "Periode %s sampai %s sudah ditutup"
+	MsgPeriodCheckFailed     = "Gagal memeriksa periode akuntansi"
+)
+
+// ========================
+// AUDIT LOG MESSAGES
+// ========================
+const (
+	MsgAuditLogsFetchSuccess = "Daftar audit log berhasil didapatkan"
+	MsgAuditLogFailed        = "Gagal menyimpan audit log"
+)
+
+// ========================
+// STOCK RECONCILIATION MESSAGES
+// ========================
+const (
+	MsgReconcileSuccess          = "Rekonsiliasi stok selesai"
+	MsgReconcileFailed           = "Gagal menjalankan rekonsiliasi stok"
+	MsgReconcileNegativeHistory  = "Riwayat transaksi menghasilkan stok negatif, perlu koreksi manual"
+	MsgReconcileStockChanged     = "Stok berubah selama rekonsiliasi, jalankan ulang"
+	MsgReconcileQuarantineDrift  = "Selisih stok karantina tidak bisa dikoreksi dengan penyesuaian, perlu koreksi manual"
+	MsgReconcilePeriodClosed     = "Periode hari ini sudah ditutup, penyesuaian tidak bisa dibuat"
+	MsgReconcileAdjustment       = "Penyesuaian rekonsiliasi stok"
+	MsgReconcileAdjustmentLocked = "Transaksi adalah penyesuaian rekonsiliasi stok dan tidak dapat diubah atau dihapus"
 )
 
 // ========================
@@ -145,7 +456,9 @@ const (
 	MsgReportHeaderUnit         = "Satuan"
 	MsgReportHeaderCurrentStock = "Stok Saat Ini"
 	MsgReportHeaderMinStock     = "Stok Minimum"
+	MsgReportHeaderQuarantine   = "Stok Karantina"
 	MsgReportHeaderStatus       = "Status Stok"
+	MsgReportHeaderAttributes   = "Atribut"
 	MsgStockStatusSafe          = "Aman"
 	MsgStockStatusLow           = "Di Bawah Minimum"
 	MsgFailedFetchItems         = "Gagal mengambil data barang"
@@ -166,10 +479,11 @@ const (
 // SUMMARY
 // ========================
 const (
-	MsgSummaryFetchedSuccess = "Jumlah inventaris berhasil didapatkan"
-	MsgSummaryTotalFailed    = "Gagal mengambil jumlah total barang"
-	MsgSummaryInFailed       = "Gagal mengambil jumlah barang masuk"
-	MsgSummaryOutFailed      = "Gagal mengambil jumlah barang keluar"
+	MsgSummaryFetchedSuccess   = "Jumlah inventaris berhasil didapatkan"
+	MsgSummaryTotalFailed      = "Gagal mengambil jumlah total barang"
+	MsgSummaryInFailed         = "Gagal mengambil jumlah barang masuk"
+	MsgSummaryOutFailed        = "Gagal mengambil jumlah barang keluar"
+	MsgSummaryQuarantineFailed = "Gagal mengambil jumlah barang karantina"
 )
 
 func GetReportTitleByType(txType string) string {
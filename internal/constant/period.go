@@ -0,0 +1,6 @@
+package constants
+
+const (
+	PeriodStatusClosed   = "closed"
+	PeriodStatusReopened = "reopened"
+)
@@ -0,0 +1,32 @@
+package constants
+
+// Izin akses yang dicek oleh route. Daftar lengkap beserta deskripsinya
+// disimpan di tabel permissions, role diberi izin lewat role_permissions.
+const (
+	PermItemsRead             = "items.read"
+	PermItemsWrite            = "items.write"
+	PermItemsMerge            = "items.merge"
+	PermMasterDataRead        = "master_data.read"
+	PermMasterDataWrite       = "master_data.write"
+	PermMasterDataStockPolicy = "master_data.stock_policy"
+	PermTransactionsRead      = "transactions.read"
+	PermTransactionsWrite     = "transactions.write"
+	PermInspectionsRead       = "inspections.read"
+	PermInspectionsWrite      = "inspections.write"
+	PermPeriodsRead           = "periods.read"
+	PermPeriodsManage         = "periods.manage"
+	PermPeriodsReopen         = "periods.reopen"
+	PermSummaryRead           = "summary.read"
+	PermReportsExport         = "reports.export"
+	PermUsersManage           = "users.manage"
+	PermRolesManage           = "roles.manage"
+	PermAPIKeysManage         = "api_keys.manage"
+	PermAuditLogsRead         = "audit_logs.read"
+	PermStockReconcile        = "stock.reconcile"
+)
+
+// AdminOnlyPermissions hanya dimiliki role admin. Izin ini tidak bisa
+// diberikan ke role lain maupun ke API key.
+var AdminOnlyPermissions = map[string]bool{
+	PermPeriodsReopen: true,
+}
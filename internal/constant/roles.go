@@ -1,7 +1,10 @@
 package constants
 
+// Role bawaan sistem. Role lain dapat dibuat admin lewat API dan izinnya
+// disimpan di tabel role_permissions.
 const (
 	RoleAdmin            = "admin"
 	RoleWarehouseAdmin   = "warehouse_admin"
 	RoleWarehouseManager = "warehouse_manager"
+	RoleServiceAccount   = "service_account"
 )
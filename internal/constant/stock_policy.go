@@ -0,0 +1,9 @@
+package constants
+
+const (
+	// Setiap pergerakan divalidasi terhadap saldo historis di tanggal efektif
+	// dan semua tanggal setelahnya
+	StockPolicyStrict = "strict"
+	// Hanya divalidasi terhadap stok saat ini
+	StockPolicyCurrentOnly = "current_only"
+)
@@ -4,3 +4,15 @@ const (
 	TransactionTypeIn  = "in"
 	TransactionTypeOut = "out"
 )
+
+// Sumber transaksi yang dibuat secara massal atau otomatis
+const (
+	ReferenceTypeImport         = "import"
+	ReferenceTypeKitAssembly    = "kit_assembly"
+	ReferenceTypeKitDisassembly = "kit_disassembly"
+
+	// ReferenceTypeStockReconciliation menandai penyesuaian yang mengoreksi
+	// stok tercatat agar sama dengan riwayat transaksi. Penyesuaian ini tidak
+	// dihitung sebagai riwayat, reference_id berisi audit_id rekonsiliasi.
+	ReferenceTypeStockReconciliation = "stock_reconciliation"
+)
@@ -0,0 +1,9 @@
+package constants
+
+// RecoveryCodeCount adalah jumlah kode pemulihan 2FA yang dibuat sekaligus.
+// Membuat ulang kode membatalkan semua kode lama.
+const RecoveryCodeCount = 10
+
+// DefaultTOTPIssuer adalah nama aplikasi yang tampil di authenticator jika
+// TOTP_ISSUER tidak diatur
+const DefaultTOTPIssuer = "Inventory App"
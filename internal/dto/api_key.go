@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyRequest struct {
+	Name           string     `json:"name" binding:"required,max=100"`
+	ServiceAccount string     `json:"service_account" binding:"required,min=5,max=255"`
+	Permissions    []string   `json:"permissions" binding:"required,min=1,dive,required"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	AllowedIPs     []string   `json:"allowed_ips" binding:"omitempty,max=50,dive,required"`
+}
+
+type APIKeyResponse struct {
+	KeyID          string     `json:"key_id"`
+	Name           string     `json:"name"`
+	KeyPrefix      string     `json:"key_prefix"`
+	ServiceAccount string     `json:"service_account"`
+	UserID         string     `json:"user_id"`
+	Permissions    []string   `json:"permissions"`
+	AllowedIPs     []string   `json:"allowed_ips"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	LastUsedIP     string     `json:"last_used_ip,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse memuat kunci asli yang hanya ditampilkan sekali
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogListRequest struct {
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Action     string `form:"action"`
+	EntityType string `form:"entity_type"`
+	EntityID   string `form:"entity_id"`
+}
+
+type AuditLogResponse struct {
+	AuditID    string          `json:"audit_id"`
+	UserID     string          `json:"user_id"`
+	Username   string          `json:"username"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Details    json.RawMessage `json:"details"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type AuditLogListResponse struct {
+	Data       []AuditLogResponse `json:"data"`
+	Pagination Pagination         `json:"pagination"`
+}
@@ -1,34 +1,61 @@
 package dto
 
 type LoginRequest struct {
-	Username string `json:"username" binding:"required,min=5"`
+	Username string `json:"username" binding:"required,min=5,max=255"`
 	Password string `json:"password" binding:"required,min=8"`
 }
 
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	Token       string       `json:"token"`
+	User        UserResponse `json:"user"`
+	Permissions []string     `json:"permissions"`
 }
 
 type UserResponse struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	FullName string `json:"fullName"`
-	Role     string `json:"role"`
+	ID                 string `json:"id"`
+	Username           string `json:"username"`
+	FullName           string `json:"fullName"`
+	Role               string `json:"role"`
+	IsActive           bool   `json:"isActive"`
+	IsServiceAccount   bool   `json:"isServiceAccount"`
+	MustChangePassword bool   `json:"mustChangePassword"`
+	TwoFactorEnabled   bool   `json:"twoFactorEnabled"`
+	AuthProvider       string `json:"authProvider"`
 }
 
 type CreateUserRequest struct {
 	Username string `json:"username" binding:"required,min=5"`
 	Password string `json:"password" binding:"required,min=8"`
 	FullName string `json:"full_name"`
-	Role     string `json:"role" binding:"required,oneof=admin warehouse_admin warehouse_manager"`
+	Role     string `json:"role" binding:"required,max=50"`
 }
 
 type UpdateUserRequest struct {
 	Username *string `json:"username" binding:"omitempty,min=5"`
 	FullName *string `json:"full_name" binding:"omitempty"`
 	Password *string `json:"password" binding:"omitempty,min=8"`
-	Role     *string `json:"role" binding:"omitempty,oneof=admin warehouse_admin warehouse_manager"`
+	Role     *string `json:"role" binding:"omitempty,max=50"`
+}
+
+type UpdateProfileRequest struct {
+	FullName string `json:"full_name" binding:"max=255"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+// ProfileResponse berisi data user yang sedang login beserta izinnya
+type ProfileResponse struct {
+	User        UserResponse `json:"user"`
+	Permissions []string     `json:"permissions"`
+}
+
+// ChangePasswordResponse memuat token baru karena token lama tidak berlaku
+// lagi setelah password diganti
+type ChangePasswordResponse struct {
+	Token string `json:"token"`
 }
 
 type UserListResponse struct {
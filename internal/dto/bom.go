@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+type SetBOMRequest struct {
+	Components []BOMComponentRequest `json:"components" binding:"omitempty,max=100,dive"`
+}
+
+type BOMComponentRequest struct {
+	ItemID   string `json:"item_id" binding:"required,uuid"`
+	Quantity int    `json:"quantity" binding:"required,min=1"`
+}
+
+type BOMComponentResponse struct {
+	ItemID   string `json:"item_id"`
+	ItemName string `json:"item_name"`
+	SKU      string `json:"sku"`
+	UnitName string `json:"unit_name"`
+	Quantity int    `json:"quantity"`
+	Stock    int    `json:"stock"`
+}
+
+type BOMResponse struct {
+	KitItemID      string                 `json:"kit_item_id"`
+	KitName        string                 `json:"kit_name"`
+	KitStock       int                    `json:"kit_stock"`
+	Components     []BOMComponentResponse `json:"components"`
+	MaxAssemblable int                    `json:"max_assemblable"`
+}
+
+type KitOperationRequest struct {
+	Quantity    int       `json:"quantity" binding:"required,min=1"`
+	Date        time.Time `json:"date" binding:"required" time_format:"2006-01-02"`
+	Description string    `json:"description"`
+}
+
+type KitShortage struct {
+	ItemID    string `json:"item_id"`
+	ItemName  string `json:"item_name"`
+	Required  int    `json:"required"`
+	Available int    `json:"available"`
+}
+
+type KitOperationResponse struct {
+	ReferenceType string                `json:"reference_type"`
+	ReferenceID   string                `json:"reference_id"`
+	KitItemID     string                `json:"kit_item_id"`
+	Quantity      int                   `json:"quantity"`
+	Transactions  []TransactionResponse `json:"transactions"`
+}
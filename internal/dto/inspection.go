@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type CreateInspectionRequest struct {
+	TransactionID    string `json:"transaction_id" binding:"required,uuid"`
+	ReleasedQuantity int    `json:"released_quantity" binding:"min=0"`
+	RejectedQuantity int    `json:"rejected_quantity" binding:"min=0"`
+	Disposition      string `json:"disposition" binding:"omitempty,oneof=return scrap"`
+	Notes            string `json:"notes"`
+}
+
+type InspectionListRequest struct {
+	Page        int    `form:"page" binding:"omitempty,min=1"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	ItemID      string `form:"item_id" binding:"omitempty,uuid"`
+	Disposition string `form:"disposition" binding:"omitempty,oneof=return scrap"`
+}
+
+type InspectionResponse struct {
+	InspectionID     string    `json:"inspection_id"`
+	TransactionID    string    `json:"transaction_id"`
+	ItemID           string    `json:"item_id"`
+	ItemName         string    `json:"item_name"`
+	ReleasedQuantity int       `json:"released_quantity"`
+	RejectedQuantity int       `json:"rejected_quantity"`
+	Disposition      string    `json:"disposition,omitempty"`
+	Notes            string    `json:"notes"`
+	InspectedBy      string    `json:"inspected_by"`
+	CurrentStock     int       `json:"current_stock"`
+	QuarantineStock  int       `json:"quarantine_stock"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type InspectionListResponse struct {
+	Data       []InspectionResponse `json:"data"`
+	Pagination Pagination           `json:"pagination"`
+}
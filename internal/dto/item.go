@@ -3,49 +3,137 @@ package dto
 import "mime/multipart"
 
 type CreateItemRequest struct {
-	ItemName     string                `form:"item_name" binding:"required"`
-	TypeID       string                `form:"type_id" binding:"required,uuid"`
-	UnitID       string                `form:"unit_id" binding:"required,uuid"`
-	MinimumStock int                   `form:"minimum_stock" binding:"min=0"`
-	Image        *multipart.FileHeader `form:"image"`
+	ItemName           string                `form:"item_name" binding:"required"`
+	SKU                string                `form:"sku"`
+	TypeID             string                `form:"type_id" binding:"required,uuid"`
+	UnitID             string                `form:"unit_id" binding:"required,uuid"`
+	MinimumStock       int                   `form:"minimum_stock" binding:"min=0"`
+	RequiresInspection bool                  `form:"requires_inspection"`
+	Image              *multipart.FileHeader `form:"image"`
+	Attributes         string                `form:"attributes"`
 }
 
 type UpdateItemRequest struct {
-	ItemName     *string               `form:"item_name" binding:"omitempty"`
-	TypeID       *string               `form:"type_id" binding:"omitempty,uuid"`
-	UnitID       *string               `form:"unit_id" binding:"omitempty,uuid"`
-	MinimumStock *int                  `form:"minimum_stock" binding:"omitempty,min=0"`
-	Image        *multipart.FileHeader `form:"image"`
+	ItemName           *string               `form:"item_name" binding:"omitempty"`
+	SKU                *string               `form:"sku"`
+	TypeID             *string               `form:"type_id" binding:"omitempty,uuid"`
+	UnitID             *string               `form:"unit_id" binding:"omitempty,uuid"`
+	MinimumStock       *int                  `form:"minimum_stock" binding:"omitempty,min=0"`
+	RequiresInspection *bool                 `form:"requires_inspection"`
+	Image              *multipart.FileHeader `form:"image"`
+	Attributes         *string               `form:"attributes"`
 }
 
 type ItemListRequest struct {
 	Page         int    `form:"page" binding:"omitempty,min=1"`
 	Limit        int    `form:"limit" binding:"omitempty,min=1,max=100"`
 	Search       string `form:"search"`
+	TypeID       string `form:"type_id" binding:"omitempty,uuid"`
 	LowStockOnly bool   `form:"low_stock_only"`
+	Archived     string `form:"archived" binding:"omitempty,oneof=active archived all"`
 }
 
 type ItemResponse struct {
-	ItemID       string `json:"item_id"`
-	ItemName     string `json:"item_name"`
-	TypeID       string `json:"type_id"`
-	TypeName     string `json:"type_name"`
-	UnitID       string `json:"unit_id"`
-	UnitName     string `json:"unit_name"`
-	Stock        int    `json:"stock"`
-	MinimumStock int    `json:"minimum_stock"`
-	Image        string `json:"image"`
-	CreatedAt    string `json:"created_at"`
-	UpdatedAt    string `json:"updated_at"`
+	ItemID             string            `json:"item_id"`
+	ItemName           string            `json:"item_name"`
+	SKU                string            `json:"sku"`
+	TypeID             string            `json:"type_id"`
+	TypeName           string            `json:"type_name"`
+	UnitID             string            `json:"unit_id"`
+	UnitName           string            `json:"unit_name"`
+	Stock              int               `json:"stock"`
+	MinimumStock       int               `json:"minimum_stock"`
+	QuarantineStock    int               `json:"quarantine_stock"`
+	RequiresInspection bool              `json:"requires_inspection"`
+	Image              string            `json:"image"`
+	ThumbnailURL       string            `json:"thumbnail_url"`
+	Attributes         map[string]string `json:"attributes,omitempty"`
+	ArchivedAt         string            `json:"archived_at,omitempty"`
+	CreatedAt          string            `json:"created_at"`
+	UpdatedAt          string            `json:"updated_at"`
 }
 
 type ItemDetailResponse struct {
 	ItemResponse
-	Type ItemTypeResponse `json:"type"`
-	Unit UnitResponse     `json:"unit"`
+	Type     ItemTypeResponse      `json:"type"`
+	Unit     UnitResponse          `json:"unit"`
+	Barcodes []ItemBarcodeResponse `json:"barcodes"`
+	Images   []ItemImageResponse   `json:"images"`
+}
+
+type AddItemImageRequest struct {
+	Image     *multipart.FileHeader `form:"image" binding:"required"`
+	IsPrimary bool                  `form:"is_primary"`
+}
+
+type ReorderItemImagesRequest struct {
+	ImageIDs []string `json:"image_ids" binding:"required,min=1,dive,uuid"`
+}
+
+type ItemImageResponse struct {
+	ImageID      string `json:"image_id"`
+	URL          string `json:"url"`
+	MediumURL    string `json:"medium_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	SortOrder    int    `json:"sort_order"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+type AddItemBarcodeRequest struct {
+	Code      string `json:"code" binding:"required"`
+	Symbology string `json:"symbology" binding:"required,oneof=ean13 code128 custom"`
+}
+
+type ItemBarcodeResponse struct {
+	BarcodeID string `json:"barcode_id"`
+	Code      string `json:"code"`
+	Symbology string `json:"symbology"`
+}
+
+type ItemLookupRequest struct {
+	Code string `form:"code" binding:"required"`
 }
 
 type ItemListResponse struct {
 	Data       []ItemResponse `json:"data"`
 	Pagination Pagination     `json:"pagination"`
 }
+
+type ImportItemRequest struct {
+	File          *multipart.FileHeader `form:"file"`
+	DryRun        bool                  `form:"dry_run"`
+	CreateMissing bool                  `form:"create_missing"`
+}
+
+type ExportItemRequest struct {
+	Format   string `form:"format" binding:"omitempty,oneof=xlsx csv"`
+	Archived string `form:"archived" binding:"omitempty,oneof=active archived all"`
+}
+
+type ImportItemRow struct {
+	Row                int               `json:"row"`
+	Action             string            `json:"action,omitempty"`
+	ItemID             string            `json:"item_id,omitempty"`
+	ItemName           string            `json:"item_name,omitempty"`
+	SKU                string            `json:"sku,omitempty"`
+	TypeName           string            `json:"item_type,omitempty"`
+	UnitName           string            `json:"unit,omitempty"`
+	MinimumStock       int               `json:"minimum_stock"`
+	RequiresInspection bool              `json:"requires_inspection"`
+	Barcodes           []string          `json:"barcodes,omitempty"`
+	Errors             map[string]string `json:"errors,omitempty"`
+}
+
+type ImportItemResponse struct {
+	DryRun       bool            `json:"dry_run"`
+	Committed    bool            `json:"committed"`
+	TotalRows    int             `json:"total_rows"`
+	ValidRows    int             `json:"valid_rows"`
+	ErrorRows    int             `json:"error_rows"`
+	Created      int             `json:"created"`
+	Updated      int             `json:"updated"`
+	Unchanged    int             `json:"unchanged"`
+	CreatedTypes []string        `json:"created_types,omitempty"`
+	CreatedUnits []string        `json:"created_units,omitempty"`
+	Rows         []ImportItemRow `json:"rows"`
+}
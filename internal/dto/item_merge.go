@@ -0,0 +1,30 @@
+package dto
+
+type MergeItemsRequest struct {
+	TargetItemID  string   `json:"target_item_id" binding:"required,uuid"`
+	SourceItemIDs []string `json:"source_item_ids" binding:"required,min=1,max=20,dive,uuid"`
+	DryRun        bool     `json:"dry_run"`
+}
+
+type MergeItemSummary struct {
+	ItemID          string `json:"item_id"`
+	ItemName        string `json:"item_name"`
+	SKU             string `json:"sku"`
+	UnitName        string `json:"unit_name"`
+	Stock           int    `json:"stock"`
+	QuarantineStock int    `json:"quarantine_stock"`
+	Transactions    int64  `json:"transactions"`
+	Barcodes        int64  `json:"barcodes"`
+}
+
+type MergeItemsResponse struct {
+	DryRun                   bool               `json:"dry_run"`
+	Committed                bool               `json:"committed"`
+	Target                   MergeItemSummary   `json:"target"`
+	Sources                  []MergeItemSummary `json:"sources"`
+	TransactionsMoved        int64              `json:"transactions_moved"`
+	BarcodesMoved            int64              `json:"barcodes_moved"`
+	ResultingStock           int                `json:"resulting_stock"`
+	ResultingQuarantineStock int                `json:"resulting_quarantine_stock"`
+	ClosedPeriodTransactions int64              `json:"closed_period_transactions"`
+}
@@ -2,24 +2,68 @@ package dto
 
 type CreateItemTypeRequest struct {
 	TypeName string `json:"type_name" binding:"required,min=3"`
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
 }
 
 type UpdateItemTypeRequest struct {
-	TypeName string `json:"type_name" binding:"required,min=3"`
+	TypeName string  `json:"type_name" binding:"required,min=3"`
+	ParentID *string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+type UpdateStockPolicyRequest struct {
+	StockPolicy string `json:"stock_policy" binding:"required,oneof=strict current_only"`
 }
 
 type ItemTypeResponse struct {
+	TypeID      string               `json:"type_id"`
+	TypeName    string               `json:"type_name"`
+	ParentID    string               `json:"parent_id,omitempty"`
+	Path        []ItemTypeBreadcrumb `json:"path,omitempty"`
+	StockPolicy string               `json:"stock_policy,omitempty"`
+	ArchivedAt  string               `json:"archived_at,omitempty"`
+}
+
+type ItemTypeBreadcrumb struct {
 	TypeID   string `json:"type_id"`
 	TypeName string `json:"type_name"`
 }
 
+type ItemTypeTreeNode struct {
+	ItemTypeResponse
+	Children []ItemTypeTreeNode `json:"children"`
+}
+
 type ItemTypeListRequest struct {
-	Page   int    `form:"page" binding:"omitempty,min=1"`
-	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
-	Search string `form:"search"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Search   string `form:"search"`
+	Archived string `form:"archived" binding:"omitempty,oneof=active archived all"`
+	ParentID string `form:"parent_id" binding:"omitempty,uuid"`
+	View     string `form:"view" binding:"omitempty,oneof=list tree"`
 }
 
 type ItemTypeListResponse struct {
 	Data       []ItemTypeResponse `json:"data"`
 	Pagination Pagination         `json:"pagination"`
 }
+
+type ItemTypeAttributeRequest struct {
+	Name     string   `json:"name" binding:"required,max=64"`
+	Label    string   `json:"label" binding:"required,max=100"`
+	DataType string   `json:"data_type" binding:"required,oneof=text number enum date"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options" binding:"omitempty,max=100,dive,required,max=100"`
+}
+
+type SetItemTypeAttributesRequest struct {
+	Attributes []ItemTypeAttributeRequest `json:"attributes" binding:"max=50,dive"`
+}
+
+type ItemTypeAttributeResponse struct {
+	AttributeID string   `json:"attribute_id"`
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	DataType    string   `json:"data_type"`
+	Required    bool     `json:"required"`
+	Options     []string `json:"options,omitempty"`
+}
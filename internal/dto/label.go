@@ -0,0 +1,23 @@
+package dto
+
+type ItemLabelRequest struct {
+	ItemIDs   []string        `json:"item_ids" binding:"required,min=1,max=500,dive,uuid"`
+	Symbology string          `json:"symbology" binding:"omitempty,oneof=code128 qr"`
+	Format    string          `json:"format" binding:"omitempty,oneof=pdf zpl"`
+	Copies    int             `json:"copies" binding:"omitempty,min=1,max=100"`
+	Layout    ItemLabelLayout `json:"layout"`
+}
+
+// ItemLabelLayout mengatur susunan label. Untuk PDF label disusun dalam
+// grid kolom x baris per halaman, untuk ZPL dipakai ukuran satu label.
+type ItemLabelLayout struct {
+	PageSize      string  `json:"page_size" binding:"omitempty,oneof=A4 Letter"`
+	Columns       int     `json:"columns" binding:"omitempty,min=1,max=10"`
+	Rows          int     `json:"rows" binding:"omitempty,min=1,max=30"`
+	MarginMM      float64 `json:"margin_mm" binding:"omitempty,min=0,max=50"`
+	GapMM         float64 `json:"gap_mm" binding:"omitempty,min=0,max=20"`
+	Skip          int     `json:"skip" binding:"omitempty,min=0"`
+	LabelWidthMM  float64 `json:"label_width_mm" binding:"omitempty,min=20,max=200"`
+	LabelHeightMM float64 `json:"label_height_mm" binding:"omitempty,min=10,max=200"`
+	DPI           int     `json:"dpi" binding:"omitempty,oneof=203 300 600"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type OIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// OIDCCallbackRequest berisi parameter yang diterima frontend dari redirect
+// identity provider
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required,max=64"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type ClosePeriodRequest struct {
+	StartDate time.Time `json:"start_date" binding:"required" time_format:"2006-01-02"`
+	EndDate   time.Time `json:"end_date" binding:"required" time_format:"2006-01-02"`
+	Note      string    `json:"note"`
+}
+
+type ReopenPeriodRequest struct {
+	Reason string `json:"reason" binding:"required,min=5"`
+}
+
+type PeriodListRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=closed reopened"`
+}
+
+type PeriodResponse struct {
+	PeriodID     string     `json:"period_id"`
+	StartDate    string     `json:"start_date"`
+	EndDate      string     `json:"end_date"`
+	Status       string     `json:"status"`
+	Note         string     `json:"note"`
+	ClosedBy     string     `json:"closed_by"`
+	ReopenedBy   string     `json:"reopened_by,omitempty"`
+	ReopenReason string     `json:"reopen_reason,omitempty"`
+	ReopenedAt   *time.Time `json:"reopened_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
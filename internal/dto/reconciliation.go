@@ -0,0 +1,25 @@
+package dto
+
+type ReconcileStockRequest struct {
+	Fix bool `form:"fix"`
+}
+
+type StockDriftResponse struct {
+	ItemID             string `json:"item_id"`
+	ItemName           string `json:"item_name"`
+	RecordedStock      int    `json:"recorded_stock"`
+	ExpectedStock      int    `json:"expected_stock"`
+	Difference         int    `json:"difference"`
+	RecordedQuarantine int    `json:"recorded_quarantine"`
+	ExpectedQuarantine int    `json:"expected_quarantine"`
+	Fixed              bool   `json:"fixed"`
+	Skipped            string `json:"skipped,omitempty"`
+	AdjustmentID       string `json:"adjustment_transaction_id,omitempty"`
+}
+
+type ReconciliationResponse struct {
+	CheckedItems int                  `json:"checked_items"`
+	DriftCount   int                  `json:"drift_count"`
+	FixedCount   int                  `json:"fixed_count"`
+	Drifts       []StockDriftResponse `json:"drifts"`
+}
@@ -3,12 +3,14 @@ package dto
 import "time"
 
 type ItemReportDTO struct {
-	ItemName     string
-	TypeName     string
-	UnitName     string
-	Stock        int
-	MinimumStock int
-	Status       string
+	ItemName        string
+	TypeName        string
+	UnitName        string
+	Stock           int
+	MinimumStock    int
+	QuarantineStock int
+	Status          string
+	Attributes      string
 }
 
 type TransactionReportDTO struct {
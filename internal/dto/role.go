@@ -0,0 +1,28 @@
+package dto
+
+type CreateRoleRequest struct {
+	Name             string   `json:"name" binding:"required,max=50"`
+	Description      string   `json:"description" binding:"max=255"`
+	Permissions      []string `json:"permissions" binding:"required,dive,required"`
+	RequireTwoFactor bool     `json:"require_two_factor"`
+}
+
+type UpdateRoleRequest struct {
+	Description      *string   `json:"description" binding:"omitempty,max=255"`
+	Permissions      *[]string `json:"permissions" binding:"omitempty,dive,required"`
+	RequireTwoFactor *bool     `json:"require_two_factor"`
+}
+
+type RoleResponse struct {
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	IsSystem         bool     `json:"is_system"`
+	RequireTwoFactor bool     `json:"require_two_factor"`
+	Permissions      []string `json:"permissions"`
+	UserCount        int64    `json:"user_count"`
+}
+
+type PermissionResponse struct {
+	Permission  string `json:"permission"`
+	Description string `json:"description"`
+}
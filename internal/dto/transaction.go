@@ -1,35 +1,51 @@
 package dto
 
-import "time"
+import (
+	"mime/multipart"
+	"time"
+)
 
 type CreateTransactionRequest struct {
-	ItemID          string    `json:"item_id" binding:"required,uuid"`
+	ItemID          string    `json:"item_id" binding:"required_without=Barcode,omitempty,uuid"`
+	Barcode         string    `json:"barcode"`
 	Date            time.Time `json:"date" binding:"required" time_format:"2006-01-02"`
 	Quantity        int       `json:"quantity" binding:"required,min=1"`
 	TransactionType string    `json:"transaction_type" binding:"required,oneof=in out"`
 	Description     string    `json:"description"`
 }
 
+type UpdateTransactionRequest struct {
+	ItemID      *string    `json:"item_id" binding:"omitempty,uuid"`
+	Date        *time.Time `json:"date" binding:"omitempty" time_format:"2006-01-02"`
+	Quantity    *int       `json:"quantity" binding:"omitempty,min=1"`
+	Description *string    `json:"description"`
+	Reason      string     `json:"reason"`
+}
+
 type TransactionListRequest struct {
-	Page       int       `form:"page" binding:"omitempty,min=1"`
-	Limit      int       `form:"limit" binding:"omitempty,min=1,max=100"`
-	Search     string    `form:"search"`
-	StartDate  time.Time `form:"start_date" time_format:"2006-01-02"`
-	EndDate    time.Time `form:"end_date" time_format:"2006-01-02"`
-	TypeFilter string    `form:"type" binding:"omitempty,oneof=in out"`
+	Page        int       `form:"page" binding:"omitempty,min=1"`
+	Limit       int       `form:"limit" binding:"omitempty,min=1,max=100"`
+	Search      string    `form:"search"`
+	StartDate   time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate     time.Time `form:"end_date" time_format:"2006-01-02"`
+	TypeFilter  string    `form:"type" binding:"omitempty,oneof=in out"`
+	ReferenceID string    `form:"reference_id" binding:"omitempty,uuid"`
 }
 
 type TransactionResponse struct {
-	TransactionID   string    `json:"transaction_id"`
-	ItemID          string    `json:"item_id"`
-	ItemName        string    `json:"item_name"`
-	Image           string    `json:"image"`
-	Date            time.Time `json:"date"`
-	Quantity        int       `json:"quantity"`
-	TransactionType string    `json:"transaction_type"`
-	Description     string    `json:"description"`
-	CurrentStock    int       `json:"current_stock"`
-	CreatedAt       time.Time `json:"created_at"`
+	TransactionID    string    `json:"transaction_id"`
+	ItemID           string    `json:"item_id"`
+	ItemName         string    `json:"item_name"`
+	Image            string    `json:"image"`
+	Date             time.Time `json:"date"`
+	Quantity         int       `json:"quantity"`
+	TransactionType  string    `json:"transaction_type"`
+	InspectionStatus string    `json:"inspection_status"`
+	Description      string    `json:"description"`
+	ReferenceType    string    `json:"reference_type,omitempty"`
+	ReferenceID      string    `json:"reference_id,omitempty"`
+	CurrentStock     int       `json:"current_stock"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type TransactionListResponse struct {
@@ -43,3 +59,40 @@ type DeleteTransactionResponse struct {
 	CurrentStock  int    `json:"current_stock"`
 	Warning       string `json:"warning,omitempty"`
 }
+
+type TransactionSnapshot struct {
+	ItemID           string `json:"item_id"`
+	Date             string `json:"date"`
+	Quantity         int    `json:"quantity"`
+	TransactionType  string `json:"transaction_type"`
+	InspectionStatus string `json:"inspection_status"`
+	Description      string `json:"description"`
+}
+
+type TransactionRevisionResponse struct {
+	RevisionID    string              `json:"revision_id"`
+	TransactionID string              `json:"transaction_id"`
+	Before        TransactionSnapshot `json:"before"`
+	After         TransactionSnapshot `json:"after"`
+	Reason        string              `json:"reason"`
+	UserID        string              `json:"user_id"`
+	Username      string              `json:"username"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+type AddTransactionAttachmentsRequest struct {
+	Files []*multipart.FileHeader `form:"files" binding:"required,min=1"`
+}
+
+type TransactionAttachmentResponse struct {
+	AttachmentID  string    `json:"attachment_id"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	ReferenceID   string    `json:"reference_id,omitempty"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	URL           string    `json:"url"`
+	UserID        string    `json:"user_id"`
+	Username      string    `json:"username"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "mime/multipart"
+
+type ImportTransactionRequest struct {
+	File   *multipart.FileHeader `form:"file"`
+	DryRun bool                  `form:"dry_run"`
+}
+
+type ImportTemplateRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=xlsx csv"`
+}
+
+type ImportTransactionRow struct {
+	Row             int               `json:"row"`
+	ItemID          string            `json:"item_id,omitempty"`
+	ItemName        string            `json:"item_name,omitempty"`
+	TransactionType string            `json:"transaction_type,omitempty"`
+	Quantity        int               `json:"quantity,omitempty"`
+	Date            string            `json:"date,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Errors          map[string]string `json:"errors,omitempty"`
+}
+
+type ImportTransactionResponse struct {
+	DryRun    bool                   `json:"dry_run"`
+	Committed bool                   `json:"committed"`
+	BatchID   string                 `json:"batch_id,omitempty"`
+	TotalRows int                    `json:"total_rows"`
+	ValidRows int                    `json:"valid_rows"`
+	ErrorRows int                    `json:"error_rows"`
+	Rows      []ImportTransactionRow `json:"rows"`
+}
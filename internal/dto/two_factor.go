@@ -0,0 +1,46 @@
+package dto
+
+type TwoFactorSetupRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// TwoFactorSetupResponse berisi secret untuk authenticator. QRCode adalah
+// data URI PNG dari ProvisioningURI.
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	QRCode          string `json:"qr_code"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,max=20"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,max=20"`
+}
+
+// RecoveryCodesResponse hanya dikirim sekali, kode tidak bisa dilihat lagi
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool  `json:"enabled"`
+	Required               bool  `json:"required"`
+	RemainingRecoveryCodes int64 `json:"remaining_recovery_codes"`
+}
+
+// LoginChallengeResponse dikirim setelah password benar untuk user dengan
+// 2FA aktif. ChallengeToken ditukar dengan token sesi di /auth/login/2fa.
+type LoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+	ExpiresIn         int    `json:"expiresIn"`
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,max=20"`
+}
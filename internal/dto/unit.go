@@ -1,8 +1,9 @@
 package dto
 
 type UnitResponse struct {
-	UnitID   string `json:"unit_id"`
-	UnitName string `json:"unit_name"`
+	UnitID     string `json:"unit_id"`
+	UnitName   string `json:"unit_name"`
+	ArchivedAt string `json:"archived_at,omitempty"`
 }
 
 type CreateUnitRequest struct {
@@ -14,9 +15,10 @@ type UpdateUnitRequest struct {
 }
 
 type UnitListRequest struct {
-	Page   int    `form:"page" binding:"omitempty,min=1"`
-	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
-	Search string `form:"search"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Search   string `form:"search"`
+	Archived string `form:"archived" binding:"omitempty,oneof=active archived all"`
 }
 
 type UnitListResponse struct {
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errNotServiceAccount = errors.New("not_service_account")
+
+type APIKeyHandler struct {
+	DB *gorm.DB
+}
+
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	var apiKeys []models.APIKey
+	if err := h.DB.Preload("User").Preload("Permissions").
+		Order("created_at DESC").
+		Find(&apiKeys).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	responses := []dto.APIKeyResponse{}
+	for _, apiKey := range apiKeys {
+		responses = append(responses, toAPIKeyResponse(apiKey))
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgAPIKeysFetchSuccess, responses)
+}
+
+// CreateAPIKey membuat API key untuk service account. Service account dibuat
+// otomatis jika username belum ada, sehingga transaksi dari integrasi
+// tercatat atas nama akun tersebut.
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"expires_at": constants.MsgAPIKeyExpiryInvalid,
+		})
+		return
+	}
+
+	allowedIPs, invalid := services.ParseAllowedIPs(req.AllowedIPs)
+	if len(invalid) > 0 {
+		validationErrors := gin.H{}
+		for i, entry := range invalid {
+			validationErrors[fmt.Sprintf("allowed_ips.%d", i)] = fmt.Sprintf(constants.MsgAPIKeyIPInvalid, entry)
+		}
+		utils.BadRequest(c, constants.MsgValidationFailed, validationErrors)
+		return
+	}
+
+	permissions, ok := resolvePermissions(c, h.DB, req.Permissions)
+	if !ok {
+		return
+	}
+
+	key, prefix, hash, err := services.GenerateAPIKey()
+	if err != nil {
+		utils.ServerError(c, constants.MsgAPIKeySaveFailed, err)
+		return
+	}
+
+	apiKey := models.APIKey{
+		KeyID:      uuid.New().String(),
+		Name:       req.Name,
+		KeyPrefix:  prefix,
+		KeyHash:    hash,
+		AllowedIPs: strings.Join(allowedIPs, ","),
+		ExpiresAt:  req.ExpiresAt,
+		CreatedBy:  userID.(string),
+	}
+	for _, permission := range permissions {
+		apiKey.Permissions = append(apiKey.Permissions, models.APIKeyPermission{
+			KeyID:      apiKey.KeyID,
+			Permission: permission,
+		})
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		account, err := findOrCreateServiceAccount(tx, req.ServiceAccount)
+		if err != nil {
+			return err
+		}
+		apiKey.UserID = account.UserID
+		apiKey.User = account
+
+		if err := tx.Omit("User").Create(&apiKey).Error; err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionAPIKeyCreate,
+			constants.AuditEntityAPIKey, apiKey.KeyID, gin.H{
+				"name":            apiKey.Name,
+				"service_account": account.Username,
+				"permissions":     permissions,
+				"allowed_ips":     allowedIPs,
+				"expires_at":      apiKey.ExpiresAt,
+			})
+	})
+	if errors.Is(err, errNotServiceAccount) {
+		utils.Error(c, http.StatusConflict, constants.MsgUsernameExists, gin.H{
+			"service_account": constants.MsgAPIKeyAccountNotService,
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgAPIKeySaveFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusCreated, constants.MsgAPIKeyCreatedSuccess, dto.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(apiKey),
+		Key:            key,
+	})
+}
+
+// RevokeAPIKey mencabut API key. Data key tetap disimpan untuk jejak audit.
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var apiKey models.APIKey
+	if err := h.DB.Preload("User").Preload("Permissions").
+		First(&apiKey, "key_id = ?", c.Param("id")).Error; err != nil {
+		utils.NotFound(c, constants.MsgAPIKeyNotFound)
+		return
+	}
+
+	if apiKey.RevokedAt != nil {
+		utils.Error(c, http.StatusConflict, constants.MsgAPIKeyAlreadyRevoked, nil)
+		return
+	}
+
+	now := time.Now()
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.APIKey{}).
+			Where("key_id = ?", apiKey.KeyID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionAPIKeyRevoke,
+			constants.AuditEntityAPIKey, apiKey.KeyID, gin.H{
+				"name": apiKey.Name,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgAPIKeySaveFailed, err)
+		return
+	}
+	apiKey.RevokedAt = &now
+
+	utils.Success(c, http.StatusOK, constants.MsgAPIKeyRevokedSuccess, toAPIKeyResponse(apiKey))
+}
+
+// findOrCreateServiceAccount mengambil service account berdasarkan username
+// atau membuatnya. Service account tidak punya password yang bisa dipakai
+// login dan izinnya diatur per API key.
+func findOrCreateServiceAccount(tx *gorm.DB, username string) (models.User, error) {
+	var account models.User
+	err := tx.Where("username = ?", username).First(&account).Error
+	if err == nil {
+		if !account.IsServiceAccount {
+			return models.User{}, errNotServiceAccount
+		}
+		return account, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, err
+	}
+
+	// Password acak yang tidak pernah diberikan ke siapa pun
+	password, _, _, err := services.GenerateAPIKey()
+	if err != nil {
+		return models.User{}, err
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	account = models.User{
+		UserID:           uuid.New().String(),
+		Username:         username,
+		Password:         string(hashedPassword),
+		FullName:         username,
+		Role:             constants.RoleServiceAccount,
+		IsServiceAccount: true,
+		IsActive:         true,
+		AuthProvider:     constants.AuthProviderLocal,
+	}
+	return account, tx.Create(&account).Error
+}
+
+func toAPIKeyResponse(apiKey models.APIKey) dto.APIKeyResponse {
+	permissions := []string{}
+	for _, permission := range apiKey.Permissions {
+		permissions = append(permissions, permission.Permission)
+	}
+	sort.Strings(permissions)
+
+	allowedIPs := []string{}
+	if apiKey.AllowedIPs != "" {
+		allowedIPs = strings.Split(apiKey.AllowedIPs, ",")
+	}
+
+	return dto.APIKeyResponse{
+		KeyID:          apiKey.KeyID,
+		Name:           apiKey.Name,
+		KeyPrefix:      apiKey.KeyPrefix,
+		ServiceAccount: apiKey.User.Username,
+		UserID:         apiKey.UserID,
+		Permissions:    permissions,
+		AllowedIPs:     allowedIPs,
+		ExpiresAt:      apiKey.ExpiresAt,
+		LastUsedAt:     apiKey.LastUsedAt,
+		LastUsedIP:     apiKey.LastUsedIP,
+		RevokedAt:      apiKey.RevokedAt,
+		CreatedAt:      apiKey.CreatedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// filterArchived menerapkan filter arsip pada query daftar data master.
+// Default hanya menampilkan data yang masih aktif.
+func filterArchived(query *gorm.DB, filter, column string) *gorm.DB {
+	switch filter {
+	case constants.ArchivedFilterAll:
+		return query
+	case constants.ArchivedFilterArchived:
+		return query.Where(column + " IS NOT NULL")
+	default:
+		return query.Where(column + " IS NULL")
+	}
+}
+
+// matchArchived menerapkan filter arsip yang sama dengan filterArchived
+// pada data yang sudah dimuat
+func matchArchived(archivedAt *time.Time, filter string) bool {
+	switch filter {
+	case constants.ArchivedFilterAll:
+		return true
+	case constants.ArchivedFilterArchived:
+		return archivedAt != nil
+	default:
+		return archivedAt == nil
+	}
+}
+
+func formatArchivedAt(archivedAt *time.Time) string {
+	if archivedAt == nil {
+		return ""
+	}
+	return archivedAt.Format(time.RFC3339)
+}
+
+// ensureItemActive menolak pergerakan stok untuk barang yang sudah diarsipkan.
+// Mengembalikan false jika response error sudah dikirim.
+func ensureItemActive(c *gin.Context, item models.Item) bool {
+	if item.ArchivedAt != nil {
+		utils.Error(c, http.StatusConflict, constants.MsgItemArchived, gin.H{
+			"item_id": fmt.Sprintf(constants.MsgItemArchivedDetail, item.ItemName),
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AuditLogHandler struct {
+	DB *gorm.DB
+}
+
+func (h *AuditLogHandler) GetAuditLogs(c *gin.Context) {
+	var req dto.AuditLogListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	// Set default values
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	offset := (req.Page - 1) * req.Limit
+
+	query := h.DB.Model(&models.AuditLog{}).
+		Preload("User").
+		Order("created_at DESC")
+
+	if req.Action != "" {
+		query = query.Where("action = ?", req.Action)
+	}
+	if req.EntityType != "" {
+		query = query.Where("entity_type = ?", req.EntityType)
+	}
+	if req.EntityID != "" {
+		query = query.Where("entity_id = ?", req.EntityID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var logs []models.AuditLog
+	if err := query.Offset(offset).Limit(req.Limit).Find(&logs).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var logResponses []dto.AuditLogResponse
+	for _, log := range logs {
+		logResponses = append(logResponses, dto.AuditLogResponse{
+			AuditID:    log.AuditID,
+			UserID:     log.UserID,
+			Username:   log.User.Username,
+			Action:     log.Action,
+			EntityType: log.EntityType,
+			EntityID:   log.EntityID,
+			Details:    json.RawMessage(log.Details),
+			CreatedAt:  log.CreatedAt,
+		})
+	}
+
+	totalPages := total / int64(req.Limit)
+	if total%int64(req.Limit) > 0 {
+		totalPages++
+	}
+
+	resp := dto.AuditLogListResponse{
+		Data: logResponses,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalData:  int(total),
+			TotalPages: int(totalPages),
+		},
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgAuditLogsFetchSuccess, resp)
+}
@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"fmt"
 	constants "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
+	"math"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -25,24 +31,72 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Cari user di database
+	// Tolak lebih awal jika username atau IP sedang dikunci
+	remaining, err := services.LoginLockRemaining(h.DB, req.Username, c.ClientIP())
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	if remaining > 0 {
+		respondLoginLocked(c, remaining)
+		return
+	}
+
+	// Cari user di database. Username tidak terdaftar, service account, user
+	// SSO dan password salah mendapat respons yang sama agar username tidak
+	// bisa ditebak dari pesan error.
 	var user models.User
 	result := h.DB.Where("username = ?", req.Username).First(&user)
-	if result.Error != nil {
-		utils.Error(c, http.StatusUnauthorized, constants.MsgInvalidCredentials, gin.H{
-			"username": constants.MsgUsernameNotFound,
-		})
+	if result.Error != nil || user.IsServiceAccount || user.AuthProvider != constants.AuthProviderLocal {
+		// Tetap hitung bcrypt agar waktu respons tidak membedakan username
+		utils.ComparePassword(dummyPasswordHash(), req.Password)
+		h.loginFailed(c, req.Username)
 		return
 	}
 
 	// Verifikasi password
 	if err := utils.ComparePassword(user.Password, req.Password); err != nil {
-		utils.Error(c, http.StatusUnauthorized, constants.MsgInvalidCredentials, gin.H{
-			"password": constants.MsgUsernameNotFound,
+		h.loginFailed(c, req.Username)
+		return
+	}
+
+	// Status nonaktif hanya diberitahukan ke pemilik password yang benar
+	if !user.IsActive {
+		utils.Error(c, http.StatusForbidden, constants.MsgUserInactive, constants.MsgForbiddenError)
+		return
+	}
+
+	h.issueLogin(c, user)
+}
+
+// issueLogin dipanggil setelah user terautentikasi (password atau SSO). User
+// dengan 2FA aktif mendapat token tantangan, token sesi baru diberikan
+// setelah kode 2FA benar di LoginTwoFactor.
+func (h *AuthHandler) issueLogin(c *gin.Context, user models.User) {
+	if user.TOTPEnabled {
+		challengeToken, ttl, err := utils.GenerateChallengeToken(user.UserID)
+		if err != nil {
+			utils.ServerError(c, constants.MsgTokenGenerationFail, err)
+			return
+		}
+		utils.Success(c, http.StatusOK, constants.MsgTwoFactorChallenge, dto.LoginChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+			ExpiresIn:         int(ttl.Seconds()),
 		})
 		return
 	}
 
+	h.completeLogin(c, user)
+}
+
+// completeLogin mereset hitungan gagal lalu mengirim token sesi
+func (h *AuthHandler) completeLogin(c *gin.Context, user models.User) {
+	if err := services.ResetLoginFailures(h.DB, user.Username); err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
 	// Generate token
 	token, err := utils.GenerateToken(user.UserID, user.Role)
 	if err != nil {
@@ -50,19 +104,81 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	// Izin efektif dikirim agar frontend bisa menyesuaikan menu
+	permissions, err := services.PermissionNames(h.DB, user.Role)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
 	resp := dto.LoginResponse{
-		Token: token,
-		User: dto.UserResponse{
-			ID:       user.UserID,
-			Username: user.Username,
-			FullName: user.FullName,
-			Role:     user.Role,
-		},
+		Token:       token,
+		Permissions: permissions,
+		User:        toUserResponse(user),
 	}
 
 	utils.Success(c, http.StatusOK, constants.MsgLoginSuccess, resp)
 }
 
+// loginFailed mencatat kegagalan login lalu mengirim respons yang sama
+// untuk semua penyebab kegagalan
+func (h *AuthHandler) loginFailed(c *gin.Context, username string) {
+	if err := services.RecordLoginFailure(h.DB, username, c.ClientIP()); err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	utils.Unauthorized(c, constants.MsgInvalidCredentials)
+}
+
+func respondLoginLocked(c *gin.Context, remaining time.Duration) {
+	seconds := int(math.Ceil(remaining.Seconds()))
+	minutes := (seconds + 59) / 60
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	utils.Error(c, http.StatusTooManyRequests, fmt.Sprintf(constants.MsgLoginLocked, minutes), gin.H{
+		"retry_after": seconds,
+		"error_code":  constants.ErrCodeLoginLocked,
+	})
+}
+
+// dummyPasswordHash dipakai untuk membandingkan password ketika username
+// tidak ditemukan
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := utils.HashPassword(uuid.New().String())
+	return hash
+})
+
+// UnlockUser membuka kunci login user yang terkunci karena terlalu banyak
+// percobaan gagal
+func (h *AuthHandler) UnlockUser(c *gin.Context) {
+	adminID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var user models.User
+	if err := h.DB.Where("user_id = ?", c.Param("id")).First(&user).Error; err != nil {
+		utils.NotFound(c, constants.MsgUserNotFound)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.ResetLoginFailures(tx, user.Username); err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, adminID.(string), constants.AuditActionUserUnlock,
+			constants.AuditEntityUser, user.UserID, gin.H{
+				"username": user.Username,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgUserSaveFail, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgUserUnlockedSuccess, nil)
+}
+
 func (h *AuthHandler) CreateUser(c *gin.Context) {
 
 	var req dto.CreateUserRequest
@@ -72,10 +188,7 @@ func (h *AuthHandler) CreateUser(c *gin.Context) {
 	}
 
 	// Validasi role
-	if !utils.IsValidRole(req.Role) {
-		utils.BadRequest(c, constants.MsgInvalidRole, gin.H{
-			"role": constants.MsgInvalidRoleValue,
-		})
+	if !ensureRoleExists(c, h.DB, req.Role) {
 		return
 	}
 
@@ -97,11 +210,13 @@ func (h *AuthHandler) CreateUser(c *gin.Context) {
 
 	// Buat user baru
 	newUser := models.User{
-		UserID:   uuid.New().String(),
-		Username: req.Username,
-		Password: string(hashedPassword),
-		FullName: req.FullName,
-		Role:     req.Role,
+		UserID:       uuid.New().String(),
+		Username:     req.Username,
+		Password:     string(hashedPassword),
+		FullName:     req.FullName,
+		Role:         req.Role,
+		IsActive:     true,
+		AuthProvider: constants.AuthProviderLocal,
 	}
 
 	if err := h.DB.Create(&newUser).Error; err != nil {
@@ -110,12 +225,7 @@ func (h *AuthHandler) CreateUser(c *gin.Context) {
 	}
 
 	// Response
-	resp := dto.UserResponse{
-		ID:       newUser.UserID,
-		Username: newUser.Username,
-		FullName: newUser.FullName,
-		Role:     newUser.Role,
-	}
+	resp := toUserResponse(newUser)
 
 	utils.Success(c, http.StatusCreated, constants.MsgUserCreatedSuccess, resp)
 }
@@ -156,15 +266,32 @@ func (h *AuthHandler) UpdateUser(c *gin.Context) {
 
 	// Update role jika ada
 	if req.Role != nil {
-		if !utils.IsValidRole(*req.Role) {
-			utils.BadRequest(c, constants.MsgInvalidRole, gin.H{
-				"role": constants.MsgInvalidRoleValue,
-			})
+		if !ensureRoleExists(c, h.DB, *req.Role) {
 			return
 		}
 		user.Role = *req.Role
 	}
 
+	// Reset password oleh admin. Token lama tidak berlaku lagi dan user wajib
+	// mengganti password setelah login, kecuali admin mengganti miliknya sendiri.
+	if req.Password != nil {
+		if user.AuthProvider != constants.AuthProviderLocal {
+			utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+				"password": constants.MsgPasswordManagedBySSO,
+			})
+			return
+		}
+		hashedPassword, err := utils.HashPassword(*req.Password)
+		if err != nil {
+			utils.ServerError(c, constants.MsgPasswordEncryptFail, err)
+			return
+		}
+		changedAt := time.Now().Truncate(time.Second)
+		user.Password = hashedPassword
+		user.PasswordChangedAt = &changedAt
+		user.MustChangePassword = user.UserID != c.GetString("userID")
+	}
+
 	// Simpan perubahan
 	if err := h.DB.Save(&user).Error; err != nil {
 		utils.ServerError(c, constants.MsgUserSaveFail, err)
@@ -172,12 +299,7 @@ func (h *AuthHandler) UpdateUser(c *gin.Context) {
 	}
 
 	// Response
-	resp := dto.UserResponse{
-		ID:       user.UserID,
-		Username: user.Username,
-		FullName: user.FullName,
-		Role:     user.Role,
-	}
+	resp := toUserResponse(user)
 
 	utils.Success(c, http.StatusOK, constants.MsgUserUpdateSuccess, resp)
 }
@@ -213,12 +335,7 @@ func (h *AuthHandler) GetUsers(c *gin.Context) {
 	// Map to response
 	var userResponses []dto.UserResponse
 	for _, user := range users {
-		userResponses = append(userResponses, dto.UserResponse{
-			ID:       user.UserID,
-			Username: user.Username,
-			FullName: user.FullName,
-			Role:     user.Role,
-		})
+		userResponses = append(userResponses, toUserResponse(user))
 	}
 
 	// Calculate pagination
@@ -249,12 +366,186 @@ func (h *AuthHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	resp := dto.UserResponse{
-		ID:       user.UserID,
-		Username: user.Username,
-		FullName: user.FullName,
-		Role:     user.Role,
-	}
+	resp := toUserResponse(user)
 
 	utils.Success(c, http.StatusOK, constants.MsgUserFetchSuccess, resp)
 }
+
+// DeactivateUser menonaktifkan user. User tidak bisa login dan token yang
+// sudah terbit langsung ditolak oleh middleware Auth.
+func (h *AuthHandler) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+func (h *AuthHandler) ActivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+func (h *AuthHandler) setUserActive(c *gin.Context, active bool) {
+	adminID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var user models.User
+	if err := h.DB.Where("user_id = ?", c.Param("id")).First(&user).Error; err != nil {
+		utils.NotFound(c, constants.MsgUserNotFound)
+		return
+	}
+
+	if !active && user.UserID == adminID.(string) {
+		utils.BadRequest(c, constants.MsgUserDeactivateSelf, nil)
+		return
+	}
+
+	action, message := constants.AuditActionUserDeactivate, constants.MsgUserDeactivated
+	if active {
+		action, message = constants.AuditActionUserActivate, constants.MsgUserActivated
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("is_active", active).Error; err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, adminID.(string), action,
+			constants.AuditEntityUser, user.UserID, gin.H{
+				"username": user.Username,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgUserSaveFail, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, message, toUserResponse(user))
+}
+
+// GetProfile mengembalikan data user yang sedang login
+func (h *AuthHandler) GetProfile(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	permissions, err := services.PermissionNames(h.DB, user.Role)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgProfileFetchSuccess, dto.ProfileResponse{
+		User:        toUserResponse(user),
+		Permissions: permissions,
+	})
+}
+
+// UpdateProfile hanya mengubah nama lengkap. Username dan role tetap
+// diatur admin.
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	user.FullName = req.FullName
+	if err := h.DB.Model(&user).Update("full_name", user.FullName).Error; err != nil {
+		utils.ServerError(c, constants.MsgUserSaveFail, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgProfileUpdated, toUserResponse(user))
+}
+
+// ChangePassword mengganti password user yang sedang login. Token lama
+// tidak berlaku lagi sehingga token baru dikirim di response.
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if user.AuthProvider != constants.AuthProviderLocal {
+		utils.BadRequest(c, constants.MsgPasswordManagedBySSO, nil)
+		return
+	}
+	if err := utils.ComparePassword(user.Password, req.CurrentPassword); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"current_password": constants.MsgPasswordCurrentWrong,
+		})
+		return
+	}
+	if req.NewPassword == req.CurrentPassword {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"new_password": constants.MsgPasswordUnchanged,
+		})
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		utils.ServerError(c, constants.MsgPasswordEncryptFail, err)
+		return
+	}
+
+	changedAt := time.Now().Truncate(time.Second)
+	if err := h.DB.Model(&user).Updates(map[string]interface{}{
+		"password":             hashedPassword,
+		"password_changed_at":  changedAt,
+		"must_change_password": false,
+	}).Error; err != nil {
+		utils.ServerError(c, constants.MsgUserSaveFail, err)
+		return
+	}
+
+	token, err := utils.GenerateToken(user.UserID, user.Role)
+	if err != nil {
+		utils.ServerError(c, constants.MsgTokenGenerationFail, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgPasswordChanged, dto.ChangePasswordResponse{Token: token})
+}
+
+// currentUser mengambil user dari sesi. Mengembalikan false jika response
+// error sudah dikirim.
+func (h *AuthHandler) currentUser(c *gin.Context) (models.User, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return models.User{}, false
+	}
+
+	var user models.User
+	if err := h.DB.Where("user_id = ?", userID).First(&user).Error; err != nil {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return models.User{}, false
+	}
+	return user, true
+}
+
+func toUserResponse(user models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:                 user.UserID,
+		Username:           user.Username,
+		FullName:           user.FullName,
+		Role:               user.Role,
+		IsActive:           user.IsActive,
+		IsServiceAccount:   user.IsServiceAccount,
+		MustChangePassword: user.MustChangePassword,
+		TwoFactorEnabled:   user.TOTPEnabled,
+		AuthProvider:       user.AuthProvider,
+	}
+}
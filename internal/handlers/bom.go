@@ -0,0 +1,421 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// kitStockError dipakai untuk membatalkan transaksi database saat stok
+// komponen atau kit tidak mencukupi
+type kitStockError struct {
+	message string
+	details gin.H
+}
+
+func (e *kitStockError) Error() string {
+	return e.message
+}
+
+func (h *ItemHandler) GetBOM(c *gin.Context) {
+	itemID := c.Param("id")
+
+	var item models.Item
+	if err := h.DB.First(&item, "item_id = ?", itemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+
+	components, err := loadBOM(h.DB, item.ItemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgBOMFetchSuccess, toBOMResponse(item, components))
+}
+
+// SetBOM mengganti seluruh daftar komponen kit. Daftar kosong menghapus BOM.
+func (h *ItemHandler) SetBOM(c *gin.Context) {
+	itemID := c.Param("id")
+
+	var req dto.SetBOMRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var item models.Item
+	if err := h.DB.First(&item, "item_id = ?", itemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+
+	// Peta kit -> komponen untuk deteksi siklus
+	var existing []models.BOMComponent
+	if err := h.DB.Find(&existing).Error; err != nil {
+		utils.ServerError(c, constants.MsgBOMUpdateFailed, err)
+		return
+	}
+	graph := make(map[string][]string)
+	for _, row := range existing {
+		graph[row.KitItemID] = append(graph[row.KitItemID], row.ComponentItemID)
+	}
+
+	validationErrors := gin.H{}
+	seen := make(map[string]int)
+	var components []models.BOMComponent
+	for i, component := range req.Components {
+		field := fmt.Sprintf("components[%d].item_id", i)
+
+		if component.ItemID == item.ItemID {
+			validationErrors[field] = constants.MsgBOMSelfReference
+			continue
+		}
+		if first, ok := seen[component.ItemID]; ok {
+			validationErrors[field] = fmt.Sprintf(constants.MsgBOMDuplicateComponent, first+1)
+			continue
+		}
+		seen[component.ItemID] = i
+
+		var componentItems []models.Item
+		if err := h.DB.Where("item_id = ?", component.ItemID).Limit(1).Find(&componentItems).Error; err != nil {
+			utils.ServerError(c, constants.MsgBOMUpdateFailed, err)
+			return
+		}
+		if len(componentItems) == 0 {
+			validationErrors[field] = constants.MsgItemNotFound
+			continue
+		}
+		if componentItems[0].ArchivedAt != nil {
+			validationErrors[field] = fmt.Sprintf(constants.MsgItemArchivedDetail, componentItems[0].ItemName)
+			continue
+		}
+
+		if bomContains(graph, component.ItemID, item.ItemID, map[string]bool{}) {
+			validationErrors[field] = fmt.Sprintf(constants.MsgBOMCycle, item.ItemName)
+			continue
+		}
+
+		components = append(components, models.BOMComponent{
+			BOMComponentID:  uuid.New().String(),
+			KitItemID:       item.ItemID,
+			ComponentItemID: component.ItemID,
+			Quantity:        component.Quantity,
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		utils.BadRequest(c, constants.MsgBOMInvalid, validationErrors)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kit_item_id = ?", item.ItemID).Delete(&models.BOMComponent{}).Error; err != nil {
+			return err
+		}
+		if len(components) > 0 {
+			if err := tx.Create(&components).Error; err != nil {
+				return err
+			}
+		}
+
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionBOMUpdate,
+			constants.AuditEntityItem, item.ItemID, gin.H{
+				"components": req.Components,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgBOMUpdateFailed, err)
+		return
+	}
+
+	saved, err := loadBOM(h.DB, item.ItemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgBOMUpdateFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgBOMUpdatedSuccess, toBOMResponse(item, saved))
+}
+
+// AssembleKit memakai stok komponen dan menambah stok kit
+func (h *ItemHandler) AssembleKit(c *gin.Context) {
+	h.runKitOperation(c, constants.ReferenceTypeKitAssembly)
+}
+
+// DisassembleKit memakai stok kit dan mengembalikan stok komponen
+func (h *ItemHandler) DisassembleKit(c *gin.Context) {
+	h.runKitOperation(c, constants.ReferenceTypeKitDisassembly)
+}
+
+func (h *ItemHandler) runKitOperation(c *gin.Context, referenceType string) {
+	itemID := c.Param("id")
+
+	var req dto.KitOperationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	if !ensureOpenPeriod(c, h.DB, req.Date) {
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var kit models.Item
+	if err := h.DB.First(&kit, "item_id = ?", itemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+	if !ensureItemActive(c, kit) {
+		return
+	}
+
+	components, err := loadBOM(h.DB, kit.ItemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgKitOperationFailed, err)
+		return
+	}
+	if len(components) == 0 {
+		utils.BadRequest(c, constants.MsgKitNoBOM, gin.H{
+			"item_id": constants.MsgKitNoBOM,
+		})
+		return
+	}
+	for _, component := range components {
+		if !ensureItemActive(c, component.Component) {
+			return
+		}
+	}
+
+	// Rakit: komponen keluar, kit masuk. Bongkar: kit keluar, komponen masuk.
+	quantities := map[string]int{kit.ItemID: req.Quantity}
+	itemIDs := []string{kit.ItemID}
+	for _, component := range components {
+		quantities[component.ComponentItemID] = component.Quantity * req.Quantity
+		itemIDs = append(itemIDs, component.ComponentItemID)
+	}
+	consumed := itemIDs[1:]
+	produced := itemIDs[:1]
+	action := constants.AuditActionKitAssemble
+	message := constants.MsgKitAssembledSuccess
+	description := fmt.Sprintf(constants.MsgKitAssemblyDescription, kit.ItemName, req.Quantity)
+	if referenceType == constants.ReferenceTypeKitDisassembly {
+		consumed, produced = produced, consumed
+		action = constants.AuditActionKitDisassemble
+		message = constants.MsgKitDisassembledSuccess
+		description = fmt.Sprintf(constants.MsgKitDisassemblyDescription, kit.ItemName, req.Quantity)
+	}
+	if req.Description != "" {
+		description = req.Description
+	}
+
+	referenceID := uuid.New().String()
+	var transactionIDs []string
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		// Kunci semua barang terkait dengan urutan tetap agar tidak deadlock
+		var locked []models.Item
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("item_id IN ?", itemIDs).
+			Order("item_id").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		lockedByID := make(map[string]models.Item, len(locked))
+		for _, item := range locked {
+			lockedByID[item.ItemID] = item
+		}
+
+		var shortages []dto.KitShortage
+		for _, id := range consumed {
+			item := lockedByID[id]
+			if item.Stock < quantities[id] {
+				shortages = append(shortages, dto.KitShortage{
+					ItemID:    item.ItemID,
+					ItemName:  item.ItemName,
+					Required:  quantities[id],
+					Available: item.Stock,
+				})
+			}
+		}
+		if len(shortages) > 0 {
+			return &kitStockError{
+				message: constants.MsgKitInsufficientComponents,
+				details: gin.H{"shortages": shortages},
+			}
+		}
+
+		for _, id := range consumed {
+			item := lockedByID[id]
+			movement := services.StockMovement{Date: req.Date, Delta: -quantities[id]}
+			negative, err := services.CheckStockBalance(tx, item, nil, []services.StockMovement{movement})
+			if err != nil {
+				return err
+			}
+			if negative != nil {
+				date := negative.Date.Format("2006-01-02")
+				return &kitStockError{
+					message: constants.MsgInsufficientStockAtDate,
+					details: gin.H{
+						"error_code": constants.ErrCodeNegativeStock,
+						"item_id":    item.ItemID,
+						"date":       date,
+						"balance":    negative.Balance,
+						"quantity":   fmt.Sprintf(constants.MsgNegativeStockDetail, negative.Balance, date),
+					},
+				}
+			}
+		}
+
+		// Transaksi keluar dibuat lebih dulu agar stok tidak sempat berlebih
+		var transactions []models.Transaction
+		for _, id := range consumed {
+			transactions = append(transactions, newKitTransaction(id, constants.TransactionTypeOut, quantities[id], req.Date, description, userID.(string), referenceType, referenceID))
+		}
+		for _, id := range produced {
+			transactions = append(transactions, newKitTransaction(id, constants.TransactionTypeIn, quantities[id], req.Date, description, userID.(string), referenceType, referenceID))
+		}
+		for i := range transactions {
+			if err := tx.Create(&transactions[i]).Error; err != nil {
+				return err
+			}
+			transactionIDs = append(transactionIDs, transactions[i].TransactionID)
+		}
+
+		return services.RecordAudit(tx, userID.(string), action,
+			constants.AuditEntityKit, referenceID, gin.H{
+				"kit_item_id": kit.ItemID,
+				"quantity":    req.Quantity,
+				"date":        req.Date.Format("2006-01-02"),
+			})
+	})
+	var stockErr *kitStockError
+	if errors.As(err, &stockErr) {
+		utils.BadRequest(c, stockErr.message, stockErr.details)
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgKitOperationFailed, err)
+		return
+	}
+
+	// Ambil ulang transaksi untuk stok terbaru setelah trigger dijalankan
+	var transactions []models.Transaction
+	if err := h.DB.Preload("Item").
+		Where("transaction_id IN ?", transactionIDs).
+		Find(&transactions).Error; err != nil {
+		utils.ServerError(c, constants.MsgKitOperationFailed, err)
+		return
+	}
+
+	resp := dto.KitOperationResponse{
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		KitItemID:     kit.ItemID,
+		Quantity:      req.Quantity,
+	}
+	for _, t := range transactions {
+		resp.Transactions = append(resp.Transactions, toTransactionResponse(t))
+	}
+
+	utils.Success(c, http.StatusCreated, message, resp)
+}
+
+// Transaksi kit adalah perpindahan internal sehingga tidak melalui karantina
+func newKitTransaction(itemID, transactionType string, quantity int, date time.Time, description, userID, referenceType, referenceID string) models.Transaction {
+	return models.Transaction{
+		TransactionID:    uuid.New().String(),
+		ItemID:           itemID,
+		Date:             date,
+		Quantity:         quantity,
+		TransactionType:  transactionType,
+		InspectionStatus: constants.InspectionStatusNone,
+		Description:      description,
+		ReferenceType:    &referenceType,
+		ReferenceID:      &referenceID,
+		UserID:           userID,
+	}
+}
+
+func loadBOM(db *gorm.DB, kitItemID string) ([]models.BOMComponent, error) {
+	var components []models.BOMComponent
+	err := db.Preload("Component.Unit").
+		Where("kit_item_id = ?", kitItemID).
+		Order("created_at ASC").
+		Find(&components).Error
+	return components, err
+}
+
+// bomContains mengecek apakah target termasuk komponen (langsung atau tidak
+// langsung) dari itemID
+func bomContains(graph map[string][]string, itemID, target string, visited map[string]bool) bool {
+	if itemID == target {
+		return true
+	}
+	if visited[itemID] {
+		return false
+	}
+	visited[itemID] = true
+	for _, child := range graph[itemID] {
+		if bomContains(graph, child, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func toBOMResponse(kit models.Item, components []models.BOMComponent) dto.BOMResponse {
+	resp := dto.BOMResponse{
+		KitItemID:  kit.ItemID,
+		KitName:    kit.ItemName,
+		KitStock:   kit.Stock,
+		Components: []dto.BOMComponentResponse{},
+	}
+
+	for i, component := range components {
+		item := dto.BOMComponentResponse{
+			ItemID:   component.ComponentItemID,
+			ItemName: component.Component.ItemName,
+			UnitName: component.Component.Unit.UnitName,
+			Quantity: component.Quantity,
+			Stock:    component.Component.Stock,
+		}
+		if component.Component.SKU != nil {
+			item.SKU = *component.Component.SKU
+		}
+		resp.Components = append(resp.Components, item)
+
+		possible := component.Component.Stock / component.Quantity
+		if possible < 0 {
+			possible = 0
+		}
+		if i == 0 || possible < resp.MaxAssemblable {
+			resp.MaxAssemblable = possible
+		}
+	}
+
+	return resp
+}
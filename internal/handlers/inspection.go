@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InspectionHandler struct {
+	DB *gorm.DB
+}
+
+var errInspectionNotPending = errors.New("inspection_not_pending")
+
+func (h *InspectionHandler) CreateInspection(c *gin.Context) {
+	var req dto.CreateInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	if req.RejectedQuantity > 0 && req.Disposition == "" {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"disposition": constants.MsgInspectionDispositionNeeded,
+		})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	// Cek transaksi exists
+	var transaction models.Transaction
+	if err := h.DB.First(&transaction, "transaction_id = ?", req.TransactionID).Error; err != nil {
+		utils.NotFound(c, constants.MsgTransactionNotFound)
+		return
+	}
+
+	// Inspeksi mengubah stok pada tanggal transaksi
+	if !ensureOpenPeriod(c, h.DB, transaction.Date) {
+		return
+	}
+
+	if req.ReleasedQuantity+req.RejectedQuantity != transaction.Quantity {
+		utils.BadRequest(c, constants.MsgInspectionQuantityMismatch, gin.H{
+			"released_quantity": fmt.Sprintf(constants.MsgInspectionQuantityDetail, transaction.Quantity),
+		})
+		return
+	}
+
+	inspection := models.Inspection{
+		InspectionID:     uuid.New().String(),
+		TransactionID:    transaction.TransactionID,
+		ItemID:           transaction.ItemID,
+		ReleasedQuantity: req.ReleasedQuantity,
+		RejectedQuantity: req.RejectedQuantity,
+		Notes:            req.Notes,
+		UserID:           userID.(string),
+	}
+	if req.RejectedQuantity > 0 {
+		inspection.Disposition = &req.Disposition
+	}
+
+	// Simpan inspeksi lalu ubah status transaksi, trigger akan memindahkan
+	// stok karantina ke stok tersedia sesuai jumlah yang diterima
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		var locked models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&locked, "transaction_id = ?", transaction.TransactionID).Error; err != nil {
+			return err
+		}
+		if locked.InspectionStatus != constants.InspectionStatusPending {
+			return errInspectionNotPending
+		}
+
+		if err := tx.Create(&inspection).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&locked).
+			Update("inspection_status", constants.InspectionStatusInspected).Error
+	})
+	if errors.Is(err, errInspectionNotPending) {
+		utils.Error(c, http.StatusConflict, constants.MsgInspectionNotPending, gin.H{
+			"transaction_id": constants.MsgInspectionNotPending,
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgInspectionCreateFailed, err)
+		return
+	}
+
+	if err := h.DB.Preload("Item").Preload("User").
+		First(&inspection, "inspection_id = ?", inspection.InspectionID).Error; err != nil {
+		utils.ServerError(c, constants.MsgInspectionCreateFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusCreated, constants.MsgInspectionCreatedSuccess, toInspectionResponse(inspection))
+}
+
+func (h *InspectionHandler) GetPendingInspections(c *gin.Context) {
+	var transactions []models.Transaction
+	if err := h.DB.Preload("Item").
+		Where("inspection_status = ?", constants.InspectionStatusPending).
+		Order("date ASC").
+		Find(&transactions).Error; err != nil {
+		utils.ServerError(c, constants.MsgFailedFetchTransactions, err)
+		return
+	}
+
+	var result []dto.TransactionResponse
+	for _, t := range transactions {
+		result = append(result, dto.TransactionResponse{
+			TransactionID:    t.TransactionID,
+			ItemID:           t.ItemID,
+			ItemName:         t.Item.ItemName,
+			Image:            itemThumbnail(t.Item),
+			Date:             t.Date,
+			Quantity:         t.Quantity,
+			TransactionType:  t.TransactionType,
+			InspectionStatus: t.InspectionStatus,
+			Description:      t.Description,
+			CurrentStock:     t.Item.Stock,
+			CreatedAt:        t.CreatedAt,
+		})
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgPendingInspectionsFetched, result)
+}
+
+func (h *InspectionHandler) GetAllInspections(c *gin.Context) {
+	var req dto.InspectionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	// Set default values
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	offset := (req.Page - 1) * req.Limit
+
+	query := h.DB.Model(&models.Inspection{}).
+		Preload("Item").
+		Preload("User").
+		Order("created_at DESC")
+
+	if req.ItemID != "" {
+		query = query.Where("item_id = ?", req.ItemID)
+	}
+
+	// Filter barang ditolak berdasarkan alur return / scrap
+	if req.Disposition != "" {
+		query = query.Where("disposition = ? AND rejected_quantity > 0", req.Disposition)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var inspections []models.Inspection
+	if err := query.Offset(offset).Limit(req.Limit).Find(&inspections).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var inspectionResponses []dto.InspectionResponse
+	for _, inspection := range inspections {
+		inspectionResponses = append(inspectionResponses, toInspectionResponse(inspection))
+	}
+
+	totalPages := total / int64(req.Limit)
+	if total%int64(req.Limit) > 0 {
+		totalPages++
+	}
+
+	resp := dto.InspectionListResponse{
+		Data: inspectionResponses,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalData:  int(total),
+			TotalPages: int(totalPages),
+		},
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgInspectionsFetchSuccess, resp)
+}
+
+func toInspectionResponse(inspection models.Inspection) dto.InspectionResponse {
+	resp := dto.InspectionResponse{
+		InspectionID:     inspection.InspectionID,
+		TransactionID:    inspection.TransactionID,
+		ItemID:           inspection.ItemID,
+		ItemName:         inspection.Item.ItemName,
+		ReleasedQuantity: inspection.ReleasedQuantity,
+		RejectedQuantity: inspection.RejectedQuantity,
+		Notes:            inspection.Notes,
+		InspectedBy:      inspection.User.FullName,
+		CurrentStock:     inspection.Item.Stock,
+		QuarantineStock:  inspection.Item.QuarantineStock,
+		CreatedAt:        inspection.CreatedAt,
+	}
+	if inspection.Disposition != nil {
+		resp.Disposition = *inspection.Disposition
+	}
+	return resp
+}
@@ -5,6 +5,7 @@ import (
 	constants "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 	"net/http"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ItemHandler struct {
@@ -35,6 +37,12 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		})
 		return
 	}
+	if itemType.ArchivedAt != nil {
+		utils.BadRequest(c, constants.MsgInvalidItemType, gin.H{
+			"type_id": constants.MsgItemTypeArchived,
+		})
+		return
+	}
 
 	// Validate unit
 	var unit models.Unit
@@ -44,65 +52,75 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		})
 		return
 	}
+	if unit.ArchivedAt != nil {
+		utils.BadRequest(c, constants.MsgInvalidUnit, gin.H{
+			"unit_id": constants.MsgUnitArchived,
+		})
+		return
+	}
+
+	// Validate SKU
+	var sku *string
+	if req.SKU != "" {
+		if !h.validateSKU(c, req.SKU, "") {
+			return
+		}
+		sku = &req.SKU
+	}
+
+	// Validasi atribut sesuai skema jenis barang
+	attributeValues, ok := resolveItemAttributes(c, h.DB, itemType.TypeID, nil, req.Attributes)
+	if !ok {
+		return
+	}
 
-	// Upload image
-	var imageURL string
+	// Upload image sebagai gambar utama galeri
+	var image *models.ItemImage
 	if req.Image != nil {
-		url, validationErrors, err := utils.ValidateAndUploadImage(req.Image, "items")
-		if err != nil {
-			switch err.Error() {
-			case "invalid_format":
-				utils.BadRequest(c, constants.MsgInvalidImageFormat, validationErrors)
-			case "too_large":
-				utils.BadRequest(c, constants.MsgImageTooLarge, validationErrors)
-			default:
-				utils.ServerError(c, constants.MsgImageUploadFailed, err)
-			}
+		uploaded, ok := uploadItemImage(c, req.Image)
+		if !ok {
 			return
 		}
-		imageURL = url
+		image = &uploaded
 	}
 
 	// Create item
 	newItem := models.Item{
-		ItemID:       uuid.New().String(),
-		TypeID:       req.TypeID,
-		UnitID:       req.UnitID,
-		ItemName:     req.ItemName,
-		Stock:        0, // Stock awal selalu 0
-		MinimumStock: req.MinimumStock,
-		Image:        imageURL,
-	}
-
-	if err := h.DB.Create(&newItem).Error; err != nil {
+		ItemID:             uuid.New().String(),
+		TypeID:             req.TypeID,
+		UnitID:             req.UnitID,
+		ItemName:           req.ItemName,
+		SKU:                sku,
+		Stock:              0, // Stock awal selalu 0
+		MinimumStock:       req.MinimumStock,
+		RequiresInspection: req.RequiresInspection,
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newItem).Error; err != nil {
+			return err
+		}
+		if image != nil {
+			image.ItemID = newItem.ItemID
+			image.IsPrimary = true
+			if err := services.AddItemImage(tx, image); err != nil {
+				return err
+			}
+			newItem.Image, newItem.ImageThumbnail = image.URL, image.ThumbnailURL
+			newItem.Images = []models.ItemImage{*image}
+		}
+		return services.SaveItemAttributes(tx, newItem.ItemID, attributeValues)
+	})
+	if err != nil {
 		utils.ServerError(c, constants.MsgItemCreatedFailed, err)
 		return
 	}
 
 	// Map ke response
-	resp := dto.ItemDetailResponse{
-		ItemResponse: dto.ItemResponse{
-			ItemID:       newItem.ItemID,
-			ItemName:     newItem.ItemName,
-			TypeID:       newItem.TypeID,
-			TypeName:     itemType.TypeName,
-			UnitID:       newItem.UnitID,
-			UnitName:     unit.UnitName,
-			Stock:        newItem.Stock,
-			MinimumStock: newItem.MinimumStock,
-			Image:        newItem.Image,
-			CreatedAt:    newItem.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    newItem.UpdatedAt.Format(time.RFC3339),
-		},
-		Type: dto.ItemTypeResponse{
-			TypeID:   itemType.TypeID,
-			TypeName: itemType.TypeName,
-		},
-		Unit: dto.UnitResponse{
-			UnitID:   unit.UnitID,
-			UnitName: unit.UnitName,
-		},
-	}
+	newItem.Type = itemType
+	newItem.Unit = unit
+	h.DB.Preload("Attribute").Where("item_id = ?", newItem.ItemID).Find(&newItem.AttributeValues)
+	resp := toItemDetailResponse(newItem)
 
 	utils.Success(c, http.StatusCreated, constants.MsgItemCreatedSuccess, resp)
 }
@@ -127,10 +145,25 @@ func (h *ItemHandler) GetAllItems(c *gin.Context) {
 	query := h.DB.Model(&models.Item{}).
 		Preload("Type").
 		Preload("Unit").
+		Preload("AttributeValues.Attribute").
 		Order("created_at DESC")
 
+	// Barang yang diarsipkan disembunyikan kecuali diminta
+	query = filterArchived(query, req.Archived, "archived_at")
+	query = filterItemAttributes(query, c.QueryMap("attr"))
+
 	if req.Search != "" {
-		query = query.Where("item_name LIKE ?", "%"+req.Search+"%")
+		query = query.Where("item_name LIKE ? OR sku LIKE ?", "%"+req.Search+"%", "%"+req.Search+"%")
+	}
+
+	// Filter jenis barang mencakup seluruh sub-jenisnya
+	if req.TypeID != "" {
+		typeIDs, err := services.TypeWithDescendants(h.DB, req.TypeID)
+		if err != nil {
+			utils.ServerError(c, constants.MsgInternalServerError, err)
+			return
+		}
+		query = query.Where("type_id IN ?", typeIDs)
 	}
 
 	if req.LowStockOnly {
@@ -151,19 +184,7 @@ func (h *ItemHandler) GetAllItems(c *gin.Context) {
 
 	var itemResponses []dto.ItemResponse
 	for _, item := range items {
-		itemResponses = append(itemResponses, dto.ItemResponse{
-			ItemID:       item.ItemID,
-			ItemName:     item.ItemName,
-			TypeID:       item.TypeID,
-			TypeName:     item.Type.TypeName,
-			UnitID:       item.UnitID,
-			UnitName:     item.Unit.UnitName,
-			Stock:        item.Stock,
-			MinimumStock: item.MinimumStock,
-			Image:        item.Image,
-			CreatedAt:    item.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    item.UpdatedAt.Format(time.RFC3339),
-		})
+		itemResponses = append(itemResponses, toItemResponse(item))
 	}
 
 	totalPages := total / int64(req.Limit)
@@ -195,10 +216,12 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 
 	// Cari item yang akan diupdate
 	var item models.Item
-	if err := h.DB.Where("item_id = ?", itemID).First(&item).Error; err != nil {
+	if err := h.DB.Preload("AttributeValues.Attribute").Where("item_id = ?", itemID).First(&item).Error; err != nil {
 		utils.NotFound(c, constants.MsgItemTypeNotFound)
 		return
 	}
+	currentAttributes := attributeValueMap(item)
+	typeChanged := false
 
 	// Update type jika ada
 	if req.TypeID != nil {
@@ -209,6 +232,13 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 			})
 			return
 		}
+		if itemType.ArchivedAt != nil && itemType.TypeID != item.TypeID {
+			utils.BadRequest(c, constants.MsgInvalidItemType, gin.H{
+				"type_id": constants.MsgItemTypeArchived,
+			})
+			return
+		}
+		typeChanged = itemType.TypeID != item.TypeID
 		item.TypeID = *req.TypeID
 	}
 
@@ -221,6 +251,12 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 			})
 			return
 		}
+		if unit.ArchivedAt != nil && unit.UnitID != item.UnitID {
+			utils.BadRequest(c, constants.MsgInvalidUnit, gin.H{
+				"unit_id": constants.MsgUnitArchived,
+			})
+			return
+		}
 		item.UnitID = *req.UnitID
 	}
 
@@ -229,67 +265,100 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		item.ItemName = *req.ItemName
 	}
 
+	// Update SKU jika ada, string kosong menghapus SKU
+	if req.SKU != nil {
+		if *req.SKU == "" {
+			item.SKU = nil
+		} else {
+			if !h.validateSKU(c, *req.SKU, item.ItemID) {
+				return
+			}
+			item.SKU = req.SKU
+		}
+	}
+
 	// Update minimum stock jika ada
 	if req.MinimumStock != nil {
 		item.MinimumStock = *req.MinimumStock
 	}
 
-	// Update gambar jika ada
-	if req.Image != nil {
-		url, validationErrors, err := utils.ValidateAndUploadImage(req.Image, "items")
-		if err != nil {
-			switch err.Error() {
-			case "invalid_format":
-				utils.BadRequest(c, constants.MsgInvalidImageFormat, validationErrors)
-			case "too_large":
-				utils.BadRequest(c, constants.MsgImageTooLarge, validationErrors)
-			default:
-				utils.ServerError(c, constants.MsgImageUploadFailed, err)
-			}
+	// Update flag inspeksi jika ada
+	if req.RequiresInspection != nil {
+		item.RequiresInspection = *req.RequiresInspection
+	}
+
+	// Atribut divalidasi ulang jika dikirim atau jenis barang berubah
+	var attributeValues map[string]string
+	if req.Attributes != nil || typeChanged {
+		var raw string
+		if req.Attributes != nil {
+			raw = *req.Attributes
+		}
+		values, ok := resolveItemAttributes(c, h.DB, item.TypeID, currentAttributes, raw)
+		if !ok {
 			return
 		}
+		attributeValues = values
+	}
 
-		item.Image = url
+	// Gambar baru menggantikan gambar utama galeri
+	var image *models.ItemImage
+	if req.Image != nil {
+		uploaded, ok := uploadItemImage(c, req.Image)
+		if !ok {
+			return
+		}
+		uploaded.ItemID = item.ItemID
+		uploaded.IsPrimary = true
+		image = &uploaded
 	}
 
 	// Simpan perubahan
-	if err := h.DB.Save(&item).Error; err != nil {
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "image", "image_thumbnail").Save(&item).Error; err != nil {
+			return err
+		}
+		if image != nil {
+			// File gambar lama dihapus dari storage setelah masa tenggang
+			var replaced []models.ItemImage
+			if err := tx.Where("item_id = ? AND is_primary = ?", item.ItemID, true).
+				Find(&replaced).Error; err != nil {
+				return err
+			}
+			if len(replaced) > 0 {
+				if err := tx.Delete(&replaced).Error; err != nil {
+					return err
+				}
+				if err := services.ScheduleItemImageDeletion(tx, replaced...); err != nil {
+					return err
+				}
+			} else if err := services.ScheduleImageDeletion(tx, item.Image); err != nil {
+				return err
+			}
+			if err := services.AddItemImage(tx, image); err != nil {
+				return err
+			}
+		}
+		if attributeValues == nil {
+			return nil
+		}
+		return services.SaveItemAttributes(tx, item.ItemID, attributeValues)
+	})
+	if err != nil {
 		utils.ServerError(c, constants.MsgItemUpdatedFailed, err)
 		return
 	}
 
 	// Get updated data dengan relasi
 	var updatedItem models.Item
-	if err := h.DB.Preload("Type").Preload("Unit").
+	if err := h.DB.Preload("Type").Preload("Unit").Preload("Barcodes").Preload("AttributeValues.Attribute").Preload("Images", orderItemImages).
 		First(&updatedItem, "item_id = ?", itemID).Error; err != nil {
 		utils.ServerError(c, constants.MsgGetUpdatedItemFailed, err)
 		return
 	}
 
 	// Map ke response
-	resp := dto.ItemDetailResponse{
-		ItemResponse: dto.ItemResponse{
-			ItemID:       updatedItem.ItemID,
-			ItemName:     updatedItem.ItemName,
-			TypeID:       updatedItem.TypeID,
-			TypeName:     updatedItem.Type.TypeName,
-			UnitID:       updatedItem.UnitID,
-			UnitName:     updatedItem.Unit.UnitName,
-			Stock:        updatedItem.Stock,
-			MinimumStock: updatedItem.MinimumStock,
-			Image:        updatedItem.Image,
-			CreatedAt:    updatedItem.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    updatedItem.UpdatedAt.Format(time.RFC3339),
-		},
-		Type: dto.ItemTypeResponse{
-			TypeID:   updatedItem.Type.TypeID,
-			TypeName: updatedItem.Type.TypeName,
-		},
-		Unit: dto.UnitResponse{
-			UnitID:   updatedItem.Unit.UnitID,
-			UnitName: updatedItem.Unit.UnitName,
-		},
-	}
+	resp := toItemDetailResponse(updatedItem)
 
 	utils.Success(c, http.StatusOK, constants.MsgItemUpdatedSuccess, resp)
 }
@@ -298,76 +367,90 @@ func (h *ItemHandler) GetItemByID(c *gin.Context) {
 	itemID := c.Param("id")
 
 	var item models.Item
-	if err := h.DB.Preload("Type").Preload("Unit").
+	if err := h.DB.Preload("Type").Preload("Unit").Preload("Barcodes").Preload("AttributeValues.Attribute").Preload("Images", orderItemImages).
 		First(&item, "item_id = ?", itemID).Error; err != nil {
 		utils.NotFound(c, constants.MsgItemNotFound)
 		return
 	}
 
-	resp := dto.ItemDetailResponse{
-		ItemResponse: dto.ItemResponse{
-			ItemID:       item.ItemID,
-			ItemName:     item.ItemName,
-			TypeID:       item.TypeID,
-			TypeName:     item.Type.TypeName,
-			UnitID:       item.UnitID,
-			UnitName:     item.Unit.UnitName,
-			Stock:        item.Stock,
-			MinimumStock: item.MinimumStock,
-			Image:        item.Image,
-			CreatedAt:    item.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    item.UpdatedAt.Format(time.RFC3339),
-		},
-		Type: dto.ItemTypeResponse{
-			TypeID:   item.Type.TypeID,
-			TypeName: item.Type.TypeName,
-		},
-		Unit: dto.UnitResponse{
-			UnitID:   item.Unit.UnitID,
-			UnitName: item.Unit.UnitName,
-		},
-	}
+	resp := toItemDetailResponse(item)
 
 	utils.Success(c, http.StatusOK, constants.MsgItemFetchSuccess, resp)
 }
 
+// DeleteItem mengarsipkan barang. Riwayat transaksi tetap utuh dan barang
+// bisa dipulihkan lewat RestoreItem.
 func (h *ItemHandler) DeleteItem(c *gin.Context) {
+	h.setItemArchived(c, true)
+}
+
+func (h *ItemHandler) RestoreItem(c *gin.Context) {
+	h.setItemArchived(c, false)
+}
+
+func (h *ItemHandler) setItemArchived(c *gin.Context, archive bool) {
 	itemID := c.Param("id")
 
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
 	// Cek apakah item ada
 	var item models.Item
-	if err := h.DB.Where("item_id = ?", itemID).First(&item).Error; err != nil {
+	if err := h.DB.Preload("Type").Preload("Unit").Where("item_id = ?", itemID).First(&item).Error; err != nil {
 		utils.NotFound(c, constants.MsgItemNotFound)
 		return
 	}
 
-	// Cek apakah item digunakan di transaksi
-	var transactionCount int64
-	if err := h.DB.Model(&models.Transaction{}).Where("item_id = ?", itemID).Count(&transactionCount).Error; err != nil {
-		utils.ServerError(c, constants.MsgItemDeleteFailed, err)
-		return
+	action, message, failed := constants.AuditActionItemArchive, constants.MsgItemArchivedSuccess, constants.MsgItemArchiveFailed
+	var archivedAt *time.Time
+	if archive {
+		if item.ArchivedAt != nil {
+			utils.Error(c, http.StatusConflict, constants.MsgItemArchived, gin.H{
+				"item_id": constants.MsgItemArchived,
+			})
+			return
+		}
+		now := time.Now()
+		archivedAt = &now
+	} else {
+		if item.ArchivedAt == nil {
+			utils.Error(c, http.StatusConflict, constants.MsgItemNotArchived, gin.H{
+				"item_id": constants.MsgItemNotArchived,
+			})
+			return
+		}
+		action, message, failed = constants.AuditActionItemRestore, constants.MsgItemRestoredSuccess, constants.MsgItemRestoreFailed
 	}
 
-	if transactionCount > 0 {
-		utils.Error(c, http.StatusConflict, constants.MsgItemInUse, gin.H{
-			"item_id": fmt.Sprintf(constants.MsgItemInUseDetail, transactionCount),
-		})
-		return
-	}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Item{}).
+			Where("item_id = ?", item.ItemID).
+			Update("archived_at", archivedAt).Error; err != nil {
+			return err
+		}
 
-	// Hapus item
-	if err := h.DB.Delete(&item).Error; err != nil {
-		utils.ServerError(c, constants.MsgItemDeleteFailed, err)
+		return services.RecordAudit(tx, userID.(string), action,
+			constants.AuditEntityItem, item.ItemID, gin.H{
+				"item_name": item.ItemName,
+				"stock":     item.Stock,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, failed, err)
 		return
 	}
 
-	utils.Success(c, http.StatusOK, constants.MsgItemDeletedSuccess, nil)
+	item.ArchivedAt = archivedAt
+	utils.Success(c, http.StatusOK, message, toItemResponse(item))
 }
 
 func (h *ItemHandler) GetLowStockItems(c *gin.Context) {
 	var items []models.Item
 	if err := h.DB.Preload("Type").
-		Where("stock < minimum_stock").
+		Where("stock < minimum_stock AND archived_at IS NULL").
 		Find(&items).Error; err != nil {
 		utils.ServerError(c, constants.MsgFailedFetchItems, err)
 		return
@@ -376,13 +459,212 @@ func (h *ItemHandler) GetLowStockItems(c *gin.Context) {
 	var result []dto.ItemResponse
 	for _, item := range items {
 		result = append(result, dto.ItemResponse{
-			ItemID:   item.ItemID,
-			ItemName: item.ItemName,
-			TypeID:   item.TypeID,
-			TypeName: item.Type.TypeName,
-			Stock:    item.Stock,
+			ItemID:          item.ItemID,
+			ItemName:        item.ItemName,
+			TypeID:          item.TypeID,
+			TypeName:        item.Type.TypeName,
+			Stock:           item.Stock,
+			QuarantineStock: item.QuarantineStock,
 		})
 	}
 
 	utils.Success(c, http.StatusOK, constants.MsgItemsFetchSuccess, result)
 }
+
+func (h *ItemHandler) LookupItem(c *gin.Context) {
+	var req dto.ItemLookupRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	found, err := services.FindItemByCode(h.DB, req.Code)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	if found == nil {
+		utils.NotFound(c, constants.MsgItemLookupNotFound)
+		return
+	}
+
+	var item models.Item
+	if err := h.DB.Preload("Type").Preload("Unit").Preload("Barcodes").Preload("AttributeValues.Attribute").Preload("Images", orderItemImages).
+		First(&item, "item_id = ?", found.ItemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgItemFetchSuccess, toItemDetailResponse(item))
+}
+
+func (h *ItemHandler) AddBarcode(c *gin.Context) {
+	itemID := c.Param("id")
+
+	var req dto.AddItemBarcodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	var item models.Item
+	if err := h.DB.Where("item_id = ?", itemID).First(&item).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+
+	if !utils.IsValidBarcode(req.Symbology, req.Code) {
+		utils.BadRequest(c, constants.MsgInvalidBarcode, gin.H{
+			"code": barcodeFormatMessage(req.Symbology),
+		})
+		return
+	}
+
+	// Cek barcode belum dipakai sebagai barcode mana pun atau SKU barang lain
+	var existing []models.ItemBarcode
+	if err := h.DB.Where("code = ?", req.Code).Limit(1).Find(&existing).Error; err != nil {
+		utils.ServerError(c, constants.MsgBarcodeAddFailed, err)
+		return
+	}
+	if len(existing) > 0 {
+		utils.Error(c, http.StatusConflict, constants.MsgBarcodeExists, gin.H{
+			"code": fmt.Sprintf(constants.MsgBarcodeExistsDetail, existing[0].ItemID),
+		})
+		return
+	}
+	usage, err := services.FindCodeUsage(h.DB, req.Code, item.ItemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgBarcodeAddFailed, err)
+		return
+	}
+	if usage != nil {
+		utils.Error(c, http.StatusConflict, constants.MsgBarcodeExists, gin.H{
+			"code": fmt.Sprintf(constants.MsgBarcodeUsedAsSKU, usage.ItemID),
+		})
+		return
+	}
+
+	barcode := models.ItemBarcode{
+		BarcodeID: uuid.New().String(),
+		ItemID:    item.ItemID,
+		Code:      req.Code,
+		Symbology: req.Symbology,
+	}
+
+	if err := h.DB.Create(&barcode).Error; err != nil {
+		utils.ServerError(c, constants.MsgBarcodeAddFailed, err)
+		return
+	}
+
+	resp := dto.ItemBarcodeResponse{
+		BarcodeID: barcode.BarcodeID,
+		Code:      barcode.Code,
+		Symbology: barcode.Symbology,
+	}
+
+	utils.Success(c, http.StatusCreated, constants.MsgBarcodeAddedSuccess, resp)
+}
+
+func (h *ItemHandler) DeleteBarcode(c *gin.Context) {
+	itemID := c.Param("id")
+	barcodeID := c.Param("barcode_id")
+
+	var barcode models.ItemBarcode
+	if err := h.DB.Where("barcode_id = ? AND item_id = ?", barcodeID, itemID).First(&barcode).Error; err != nil {
+		utils.NotFound(c, constants.MsgBarcodeNotFound)
+		return
+	}
+
+	if err := h.DB.Delete(&barcode).Error; err != nil {
+		utils.ServerError(c, constants.MsgBarcodeDeleteFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgBarcodeDeletedSuccess, nil)
+}
+
+// validateSKU mengecek format dan keunikan SKU, excludeItemID diisi saat update.
+// Mengembalikan false jika response error sudah dikirim.
+func (h *ItemHandler) validateSKU(c *gin.Context, sku, excludeItemID string) bool {
+	if !utils.IsValidSKU(sku) {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"sku": constants.MsgInvalidSKU,
+		})
+		return false
+	}
+
+	// SKU tidak boleh sama dengan SKU atau barcode barang lain
+	usage, err := services.FindCodeUsage(h.DB, sku, excludeItemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return false
+	}
+	if usage != nil {
+		detail := constants.MsgSKUExists
+		if usage.Barcode {
+			detail = fmt.Sprintf(constants.MsgSKUUsedAsBarcode, usage.ItemID)
+		}
+		utils.Error(c, http.StatusConflict, constants.MsgSKUExists, gin.H{
+			"sku": detail,
+		})
+		return false
+	}
+	return true
+}
+
+func barcodeFormatMessage(symbology string) string {
+	switch symbology {
+	case constants.SymbologyEAN13:
+		return constants.MsgInvalidBarcodeEAN13
+	case constants.SymbologyCode128:
+		return constants.MsgInvalidBarcode128
+	default:
+		return constants.MsgInvalidBarcodeCustom
+	}
+}
+
+func toItemResponse(item models.Item) dto.ItemResponse {
+	var sku string
+	if item.SKU != nil {
+		sku = *item.SKU
+	}
+
+	return dto.ItemResponse{
+		ItemID:             item.ItemID,
+		ItemName:           item.ItemName,
+		SKU:                sku,
+		TypeID:             item.TypeID,
+		TypeName:           item.Type.TypeName,
+		UnitID:             item.UnitID,
+		UnitName:           item.Unit.UnitName,
+		Stock:              item.Stock,
+		MinimumStock:       item.MinimumStock,
+		QuarantineStock:    item.QuarantineStock,
+		RequiresInspection: item.RequiresInspection,
+		Image:              signImageURL(item.Image),
+		ThumbnailURL:       itemThumbnail(item),
+		Attributes:         attributeValueMap(item),
+		ArchivedAt:         formatArchivedAt(item.ArchivedAt),
+		CreatedAt:          item.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          item.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toItemDetailResponse(item models.Item) dto.ItemDetailResponse {
+	barcodes := []dto.ItemBarcodeResponse{}
+	for _, barcode := range item.Barcodes {
+		barcodes = append(barcodes, dto.ItemBarcodeResponse{
+			BarcodeID: barcode.BarcodeID,
+			Code:      barcode.Code,
+			Symbology: barcode.Symbology,
+		})
+	}
+
+	return dto.ItemDetailResponse{
+		ItemResponse: toItemResponse(item),
+		Type:         toItemTypeResponse(item.Type),
+		Unit:         toUnitResponse(item.Unit),
+		Barcodes:     barcodes,
+		Images:       toItemImageResponses(item.Images),
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// resolveItemAttributes memvalidasi input atribut barang terhadap skema
+// jenis barangnya dan mengembalikan nilai final per attribute_id. Response
+// error dikirim langsung jika tidak valid.
+func resolveItemAttributes(c *gin.Context, db *gorm.DB, typeID string, current map[string]string, raw string) (map[string]string, bool) {
+	input := map[string]string{}
+	if strings.TrimSpace(raw) != "" {
+		parsed, err := services.ParseAttributeInput(raw)
+		if err != nil {
+			utils.BadRequest(c, constants.MsgItemAttributesInvalid, gin.H{
+				"attributes": constants.MsgItemAttributesMalformed,
+			})
+			return nil, false
+		}
+		input = parsed
+	}
+
+	attributes, err := services.TypeAttributes(db, typeID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return nil, false
+	}
+
+	values, validationErrors := services.ResolveAttributeValues(attributes, current, input)
+	if len(validationErrors) > 0 {
+		utils.BadRequest(c, constants.MsgItemAttributesInvalid, validationErrors)
+		return nil, false
+	}
+	return values, true
+}
+
+// filterItemAttributes menyaring barang dengan query attr[nama]=nilai. Teks
+// dibandingkan tanpa membedakan huruf besar, angka dibandingkan nilainya.
+func filterItemAttributes(query *gorm.DB, filters map[string]string) *gorm.DB {
+	for name, value := range filters {
+		value = strings.TrimSpace(value)
+		number := value
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			number = strconv.FormatFloat(parsed, 'f', -1, 64)
+		}
+
+		query = query.Where(`item_id IN (SELECT v.item_id FROM item_attribute_values v
+			JOIN item_type_attributes a ON a.attribute_id = v.attribute_id
+			WHERE a.name = ? AND (LOWER(v.value) = LOWER(?) OR (a.data_type = ? AND v.value = ?)))`,
+			name, value, constants.AttributeTypeNumber, number)
+	}
+	return query
+}
+
+// attributeValueMap memetakan nilai atribut barang per nama atribut.
+// AttributeValues.Attribute harus sudah di-preload.
+func attributeValueMap(item models.Item) map[string]string {
+	if len(item.AttributeValues) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(item.AttributeValues))
+	for _, value := range item.AttributeValues {
+		if value.Attribute.TypeID != item.TypeID {
+			continue
+		}
+		values[value.Attribute.Name] = value.Value
+	}
+	return values
+}
+
+// formatAttributes menggabungkan atribut barang menjadi satu teks untuk laporan
+func formatAttributes(item models.Item) string {
+	values := make([]models.ItemAttributeValue, 0, len(item.AttributeValues))
+	for _, value := range item.AttributeValues {
+		if value.Attribute.TypeID == item.TypeID {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Attribute.SortOrder < values[j].Attribute.SortOrder
+	})
+
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, value.Attribute.Label+": "+value.Value)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"inventory_app_backend/pkg/firebase"
+	"log"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errImageLimit = errors.New("image_limit")
+
+func (h *ItemHandler) GetItemImages(c *gin.Context) {
+	itemID := c.Param("id")
+
+	var item models.Item
+	if err := h.DB.First(&item, "item_id = ?", itemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+
+	images, err := loadItemImages(h.DB, item.ItemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgItemImagesFetchSuccess, toItemImageResponses(images))
+}
+
+// AddItemImage menambahkan gambar ke galeri barang beserta varian medium
+// dan thumbnail
+func (h *ItemHandler) AddItemImage(c *gin.Context) {
+	itemID := c.Param("id")
+
+	var req dto.AddItemImageRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	var item models.Item
+	if err := h.DB.First(&item, "item_id = ?", itemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+
+	// Cek batas galeri sebelum upload agar tidak ada file yang sia-sia
+	var total int64
+	if err := h.DB.Model(&models.ItemImage{}).Where("item_id = ?", item.ItemID).Count(&total).Error; err != nil {
+		utils.ServerError(c, constants.MsgItemImageFailed, err)
+		return
+	}
+	if total >= constants.MaxItemImages {
+		utils.BadRequest(c, constants.MsgItemImageFailed, gin.H{
+			"image": fmt.Sprintf(constants.MsgItemImageLimit, constants.MaxItemImages),
+		})
+		return
+	}
+
+	image, ok := uploadItemImage(c, req.Image)
+	if !ok {
+		return
+	}
+	image.ItemID = item.ItemID
+	image.IsPrimary = req.IsPrimary
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Kunci barang agar upload bersamaan tidak melewati batas galeri
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&models.Item{}, "item_id = ?", item.ItemID).Error; err != nil {
+			return err
+		}
+		var total int64
+		if err := tx.Model(&models.ItemImage{}).Where("item_id = ?", item.ItemID).Count(&total).Error; err != nil {
+			return err
+		}
+		if total >= constants.MaxItemImages {
+			return errImageLimit
+		}
+
+		return services.AddItemImage(tx, &image)
+	})
+	if errors.Is(err, errImageLimit) {
+		utils.BadRequest(c, constants.MsgItemImageFailed, gin.H{
+			"image": fmt.Sprintf(constants.MsgItemImageLimit, constants.MaxItemImages),
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemImageFailed, err)
+		return
+	}
+
+	h.respondItemImages(c, item.ItemID, http.StatusCreated, constants.MsgItemImageAddedSuccess)
+}
+
+func (h *ItemHandler) SetPrimaryItemImage(c *gin.Context) {
+	itemID := c.Param("id")
+	imageID := c.Param("image_id")
+
+	var image models.ItemImage
+	if err := h.DB.Where("image_id = ? AND item_id = ?", imageID, itemID).First(&image).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemImageNotFound)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ItemImage{}).
+			Where("item_id = ?", image.ItemID).
+			Update("is_primary", gorm.Expr("image_id = ?", image.ImageID)).Error; err != nil {
+			return err
+		}
+		return services.SyncPrimaryImage(tx, image.ItemID)
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemImageFailed, err)
+		return
+	}
+
+	h.respondItemImages(c, image.ItemID, http.StatusOK, constants.MsgItemImagePrimarySuccess)
+}
+
+// ReorderItemImages mengatur ulang urutan galeri. Daftar harus memuat
+// seluruh gambar barang tepat satu kali.
+func (h *ItemHandler) ReorderItemImages(c *gin.Context) {
+	itemID := c.Param("id")
+
+	var req dto.ReorderItemImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	images, err := loadItemImages(h.DB, itemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemImageFailed, err)
+		return
+	}
+	if len(images) == 0 {
+		utils.NotFound(c, constants.MsgItemImageNotFound)
+		return
+	}
+
+	existing := make(map[string]bool, len(images))
+	for _, image := range images {
+		existing[image.ImageID] = true
+	}
+	seen := make(map[string]bool, len(req.ImageIDs))
+	for _, id := range req.ImageIDs {
+		if !existing[id] || seen[id] {
+			break
+		}
+		seen[id] = true
+	}
+	if len(req.ImageIDs) != len(images) || len(seen) != len(images) {
+		utils.BadRequest(c, constants.MsgItemImageFailed, gin.H{
+			"image_ids": constants.MsgItemImageOrderInvalid,
+		})
+		return
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		for i, id := range req.ImageIDs {
+			if err := tx.Model(&models.ItemImage{}).
+				Where("image_id = ?", id).
+				Update("sort_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemImageFailed, err)
+		return
+	}
+
+	h.respondItemImages(c, itemID, http.StatusOK, constants.MsgItemImagesReorderedSuccess)
+}
+
+// DeleteItemImage menghapus gambar dari galeri. Jika gambar utama dihapus,
+// gambar berikutnya menjadi utama. File di storage dihapus setelah masa
+// tenggang oleh RunPendingDeletions.
+func (h *ItemHandler) DeleteItemImage(c *gin.Context) {
+	itemID := c.Param("id")
+	imageID := c.Param("image_id")
+
+	var image models.ItemImage
+	if err := h.DB.Where("image_id = ? AND item_id = ?", imageID, itemID).First(&image).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemImageNotFound)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&image).Error; err != nil {
+			return err
+		}
+		if err := services.ScheduleItemImageDeletion(tx, image); err != nil {
+			return err
+		}
+		return services.SyncPrimaryImage(tx, image.ItemID)
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemImageFailed, err)
+		return
+	}
+
+	h.respondItemImages(c, image.ItemID, http.StatusOK, constants.MsgItemImageDeletedSuccess)
+}
+
+func (h *ItemHandler) respondItemImages(c *gin.Context, itemID string, status int, message string) {
+	images, err := loadItemImages(h.DB, itemID)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	utils.Success(c, status, message, toItemImageResponses(images))
+}
+
+// uploadItemImage mengupload gambar beserta variannya. Mengembalikan false
+// jika response error sudah dikirim.
+func uploadItemImage(c *gin.Context, file *multipart.FileHeader) (models.ItemImage, bool) {
+	variants, validationErrors, err := utils.UploadImageWithVariants(file, "items")
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrImageFormat), errors.Is(err, utils.ErrImageCorrupt), errors.Is(err, utils.ErrImageEmpty):
+			utils.BadRequest(c, constants.MsgInvalidImageFormat, validationErrors)
+		case errors.Is(err, utils.ErrImageTooLarge), errors.Is(err, utils.ErrImageDimensions):
+			utils.BadRequest(c, constants.MsgImageTooLarge, validationErrors)
+		default:
+			utils.ServerError(c, constants.MsgImageUploadFailed, err)
+		}
+		return models.ItemImage{}, false
+	}
+
+	return models.ItemImage{
+		ImageID:      uuid.New().String(),
+		URL:          variants.Original,
+		MediumURL:    variants.Medium,
+		ThumbnailURL: variants.Thumbnail,
+	}, true
+}
+
+func loadItemImages(db *gorm.DB, itemID string) ([]models.ItemImage, error) {
+	var images []models.ItemImage
+	err := orderItemImages(db.Where("item_id = ?", itemID)).Find(&images).Error
+	return images, err
+}
+
+func orderItemImages(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, created_at ASC")
+}
+
+// itemThumbnail mengembalikan signed URL thumbnail untuk tampilan daftar.
+// Barang lama yang belum punya galeri memakai gambar aslinya.
+func itemThumbnail(item models.Item) string {
+	if item.ImageThumbnail != "" {
+		return signImageURL(item.ImageThumbnail)
+	}
+	return signImageURL(item.Image)
+}
+
+// signImageURL mengubah URL objek di bucket menjadi signed URL sementara.
+// URL di luar bucket aplikasi dikembalikan apa adanya.
+func signImageURL(url string) string {
+	path := firebase.ObjectPath(url)
+	if path == "" {
+		return url
+	}
+
+	signed, err := firebase.SignedURL(path)
+	if err != nil {
+		log.Printf("Gagal membuat signed URL untuk %s: %v", path, err)
+		return ""
+	}
+	return signed
+}
+
+func toItemImageResponses(images []models.ItemImage) []dto.ItemImageResponse {
+	responses := []dto.ItemImageResponse{}
+	for _, image := range images {
+		responses = append(responses, dto.ItemImageResponse{
+			ImageID:      image.ImageID,
+			URL:          signImageURL(image.URL),
+			MediumURL:    signImageURL(image.MediumURL),
+			ThumbnailURL: signImageURL(image.ThumbnailURL),
+			SortOrder:    image.SortOrder,
+			IsPrimary:    image.IsPrimary,
+		})
+	}
+	return responses
+}
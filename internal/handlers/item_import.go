@@ -0,0 +1,726 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kolom yang sama dipakai untuk ekspor dan impor agar file hasil ekspor bisa
+// langsung diimpor kembali tanpa perubahan
+var itemImportColumns = []string{"item_id", "sku", "item_name", "item_type", "unit", "minimum_stock", "requires_inspection", "barcodes", "attributes"}
+
+type itemImporter struct {
+	db            *gorm.DB
+	createMissing bool
+	types         map[string]*models.ItemType
+	attributes    map[string][]models.ItemTypeAttribute
+	units         map[string]*models.Unit
+	newTypes      []*models.ItemType
+	newUnits      []*models.Unit
+	seen          map[string]int
+}
+
+type itemImportPlan struct {
+	item   models.Item
+	action string
+
+	addBarcodes      []models.ItemBarcode
+	removeBarcodeIDs []string
+	// attributes berisi nilai atribut final per attribute_id, nil berarti
+	// atribut barang tidak diubah
+	attributes map[string]string
+}
+
+func (h *ItemHandler) ExportItems(c *gin.Context) {
+	var req dto.ExportItemRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	query := h.DB.Preload("Type").Preload("Unit").Preload("Barcodes").Preload("AttributeValues.Attribute").
+		Order("item_name ASC")
+	query = filterArchived(query, req.Archived, "archived_at")
+
+	var items []models.Item
+	if err := query.Find(&items).Error; err != nil {
+		utils.ServerError(c, constants.MsgFailedFetchItems, err)
+		return
+	}
+
+	var rows [][]string
+	for _, item := range items {
+		rows = append(rows, itemExportRow(item))
+	}
+
+	filename := constants.MsgItemExportFilename + time.Now().Format("20060102_150405")
+	writeSpreadsheet(c, req.Format, filename, itemImportColumns, rows)
+}
+
+func itemExportRow(item models.Item) []string {
+	var sku string
+	if item.SKU != nil {
+		sku = *item.SKU
+	}
+
+	return []string{
+		item.ItemID,
+		sku,
+		item.ItemName,
+		item.Type.TypeName,
+		item.Unit.UnitName,
+		strconv.Itoa(item.MinimumStock),
+		strconv.FormatBool(item.RequiresInspection),
+		formatImportBarcodes(item.Barcodes),
+		formatImportAttributes(item),
+	}
+}
+
+func (h *ItemHandler) ImportItems(c *gin.Context) {
+	var req dto.ImportItemRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	if req.File == nil {
+		utils.BadRequest(c, constants.MsgImportFileRequired, gin.H{
+			"file": constants.MsgFieldRequired,
+		})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	header, rows, ok := readImportFile(c, req.File, []string{"item_name", "item_type", "unit"})
+	if !ok {
+		return
+	}
+
+	importer := &itemImporter{
+		db:            h.DB,
+		createMissing: req.CreateMissing,
+		types:         make(map[string]*models.ItemType),
+		attributes:    make(map[string][]models.ItemTypeAttribute),
+		units:         make(map[string]*models.Unit),
+		seen:          make(map[string]int),
+	}
+
+	resp := dto.ImportItemResponse{DryRun: req.DryRun}
+	var plans []itemImportPlan
+	for i, row := range rows {
+		if utils.IsBlankRow(row) {
+			continue
+		}
+
+		result, plan, err := importer.validateRow(i+2, header, row)
+		if err != nil {
+			utils.ServerError(c, constants.MsgImportFailed, err)
+			return
+		}
+
+		resp.TotalRows++
+		if len(result.Errors) > 0 {
+			resp.ErrorRows++
+		} else {
+			resp.ValidRows++
+			plans = append(plans, plan)
+			switch plan.action {
+			case constants.ImportActionCreate:
+				resp.Created++
+			case constants.ImportActionUpdate:
+				resp.Updated++
+			default:
+				resp.Unchanged++
+			}
+		}
+		resp.Rows = append(resp.Rows, result)
+	}
+
+	for _, itemType := range importer.newTypes {
+		resp.CreatedTypes = append(resp.CreatedTypes, itemType.TypeName)
+	}
+	for _, unit := range importer.newUnits {
+		resp.CreatedUnits = append(resp.CreatedUnits, unit.UnitName)
+	}
+
+	if resp.TotalRows == 0 {
+		utils.BadRequest(c, constants.MsgImportEmpty, gin.H{
+			"file": constants.MsgImportEmpty,
+		})
+		return
+	}
+
+	if req.DryRun {
+		utils.Success(c, http.StatusOK, constants.MsgImportValidated, resp)
+		return
+	}
+
+	// Semua baris harus valid, jika tidak maka tidak ada yang disimpan
+	if resp.ErrorRows > 0 {
+		utils.Error(c, http.StatusUnprocessableEntity, constants.MsgImportHasErrors, resp)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		for _, itemType := range importer.newTypes {
+			if err := tx.Create(itemType).Error; err != nil {
+				return err
+			}
+		}
+		for _, unit := range importer.newUnits {
+			if err := tx.Create(unit).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, plan := range plans {
+			switch plan.action {
+			case constants.ImportActionCreate:
+				if err := tx.Create(&plan.item).Error; err != nil {
+					return err
+				}
+			case constants.ImportActionUpdate:
+				if err := tx.Model(&models.Item{}).
+					Where("item_id = ?", plan.item.ItemID).
+					Updates(map[string]interface{}{
+						"item_name":           plan.item.ItemName,
+						"sku":                 plan.item.SKU,
+						"type_id":             plan.item.TypeID,
+						"unit_id":             plan.item.UnitID,
+						"minimum_stock":       plan.item.MinimumStock,
+						"requires_inspection": plan.item.RequiresInspection,
+					}).Error; err != nil {
+					return err
+				}
+			}
+
+			if len(plan.removeBarcodeIDs) > 0 {
+				if err := tx.Where("barcode_id IN ?", plan.removeBarcodeIDs).
+					Delete(&models.ItemBarcode{}).Error; err != nil {
+					return err
+				}
+			}
+			if len(plan.addBarcodes) > 0 {
+				if err := tx.Create(&plan.addBarcodes).Error; err != nil {
+					return err
+				}
+			}
+			if plan.attributes != nil {
+				if err := services.SaveItemAttributes(tx, plan.item.ItemID, plan.attributes); err != nil {
+					return err
+				}
+			}
+		}
+
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionItemImport,
+			constants.AuditEntityImport, uuid.New().String(), gin.H{
+				"file_name":     req.File.Filename,
+				"created":       resp.Created,
+				"updated":       resp.Updated,
+				"created_types": resp.CreatedTypes,
+				"created_units": resp.CreatedUnits,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgImportFailed, err)
+		return
+	}
+
+	resp.Committed = true
+	utils.Success(c, http.StatusCreated, constants.MsgImportCommitted, resp)
+}
+
+func (imp *itemImporter) validateRow(rowNumber int, header, row []string) (dto.ImportItemRow, itemImportPlan, error) {
+	result := dto.ImportItemRow{
+		Row:      rowNumber,
+		ItemID:   utils.SpreadsheetCell(header, row, "item_id"),
+		SKU:      utils.SpreadsheetCell(header, row, "sku"),
+		ItemName: utils.SpreadsheetCell(header, row, "item_name"),
+		TypeName: utils.SpreadsheetCell(header, row, "item_type"),
+		UnitName: utils.SpreadsheetCell(header, row, "unit"),
+		Errors:   map[string]string{},
+	}
+	var plan itemImportPlan
+
+	if result.ItemName == "" {
+		result.Errors["item_name"] = constants.MsgFieldRequired
+	}
+
+	if result.SKU != "" && !utils.IsValidSKU(result.SKU) {
+		result.Errors["sku"] = constants.MsgInvalidSKU
+	}
+
+	// Kolom opsional yang kosong atau tidak ada tidak mengubah nilai barang
+	// yang sudah ada, barang baru memakai nilai default
+	var minimumStock *int
+	if value := utils.SpreadsheetCell(header, row, "minimum_stock"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			result.Errors["minimum_stock"] = constants.MsgImportInvalidMinStock
+		}
+		minimumStock = &parsed
+	}
+
+	var requiresInspection *bool
+	if value := utils.SpreadsheetCell(header, row, "requires_inspection"); value != "" {
+		parsed, ok := parseImportBool(value)
+		if !ok {
+			result.Errors["requires_inspection"] = constants.MsgImportInvalidBoolean
+		}
+		requiresInspection = &parsed
+	}
+
+	var barcodes []models.ItemBarcode
+	if value := utils.SpreadsheetCell(header, row, "barcodes"); value != "" {
+		parsed, msg := parseImportBarcodes(value)
+		if msg != "" {
+			result.Errors["barcodes"] = msg
+		}
+		barcodes = parsed
+	}
+
+	var attributeInput map[string]string
+	if value := utils.SpreadsheetCell(header, row, "attributes"); value != "" {
+		parsed, err := services.ParseAttributeInput(value)
+		if err != nil {
+			result.Errors["attributes"] = constants.MsgItemAttributesMalformed
+		}
+		attributeInput = parsed
+	}
+
+	itemType, err := imp.resolveType(result.TypeName, result.Errors)
+	if err != nil {
+		return result, plan, err
+	}
+	unit, err := imp.resolveUnit(result.UnitName, result.Errors)
+	if err != nil {
+		return result, plan, err
+	}
+
+	// Cari barang yang sudah ada berdasarkan ID, SKU, lalu nama
+	itemQuery := imp.db.Preload("Barcodes").Preload("AttributeValues.Attribute")
+	var existing *models.Item
+	if result.ItemID != "" {
+		var item models.Item
+		if err := itemQuery.Where("item_id = ?", result.ItemID).First(&item).Error; err != nil {
+			result.Errors["item_id"] = constants.MsgItemNotFound
+		} else {
+			existing = &item
+		}
+	} else if result.SKU != "" && result.Errors["sku"] == "" {
+		var items []models.Item
+		if err := itemQuery.Where("sku = ?", result.SKU).Limit(1).Find(&items).Error; err != nil {
+			return result, plan, err
+		}
+		if len(items) == 1 {
+			existing = &items[0]
+		}
+	}
+	if existing == nil && result.ItemID == "" && result.ItemName != "" {
+		var items []models.Item
+		if err := itemQuery.Where("LOWER(item_name) = ?", strings.ToLower(result.ItemName)).
+			Limit(2).Find(&items).Error; err != nil {
+			return result, plan, err
+		}
+		if len(items) > 1 {
+			result.Errors["item_name"] = constants.MsgImportItemAmbiguous
+		} else if len(items) == 1 {
+			existing = &items[0]
+		}
+	}
+
+	// Barang yang diarsipkan harus dipulihkan dulu sebelum diubah lewat impor
+	if existing != nil && existing.ArchivedAt != nil {
+		result.Errors["item_id"] = constants.MsgItemArchived
+	}
+
+	// SKU dan barcode berbagi satu ruang kode sehingga tidak boleh dipakai
+	// barang lain atau muncul dua kali di file
+	var excludeItemID string
+	if existing != nil {
+		excludeItemID = existing.ItemID
+	}
+	if result.SKU != "" && result.Errors["sku"] == "" {
+		usage, err := services.FindCodeUsage(imp.db, result.SKU, excludeItemID)
+		if err != nil {
+			return result, plan, err
+		}
+		if usage != nil && usage.Barcode {
+			result.Errors["sku"] = fmt.Sprintf(constants.MsgSKUUsedAsBarcode, usage.ItemID)
+		} else if usage != nil {
+			result.Errors["sku"] = constants.MsgSKUExists
+		} else if firstRow, ok := imp.seen["code:"+result.SKU]; ok {
+			result.Errors["sku"] = fmt.Sprintf(constants.MsgImportDuplicateRow, firstRow)
+		} else {
+			imp.seen["code:"+result.SKU] = rowNumber
+		}
+	}
+	if result.Errors["barcodes"] == "" {
+		for _, barcode := range barcodes {
+			usage, err := services.FindCodeUsage(imp.db, barcode.Code, excludeItemID)
+			if err != nil {
+				return result, plan, err
+			}
+			if usage != nil && usage.Barcode {
+				result.Errors["barcodes"] = fmt.Sprintf(constants.MsgBarcodeExistsDetail, usage.ItemID)
+				break
+			}
+			if usage != nil {
+				result.Errors["barcodes"] = fmt.Sprintf(constants.MsgBarcodeUsedAsSKU, usage.ItemID)
+				break
+			}
+			// SKU barang yang sama boleh dicetak juga sebagai barcode
+			if firstRow, ok := imp.seen["code:"+barcode.Code]; ok && firstRow != rowNumber {
+				result.Errors["barcodes"] = fmt.Sprintf(constants.MsgImportDuplicateRow, firstRow)
+				break
+			}
+			imp.seen["code:"+barcode.Code] = rowNumber
+		}
+	}
+
+	// Satu barang hanya boleh muncul sekali dalam file
+	key := strings.ToLower(result.ItemName)
+	if existing != nil {
+		key = existing.ItemID
+	}
+	if key != "" {
+		if firstRow, ok := imp.seen[key]; ok {
+			result.Errors["item_name"] = fmt.Sprintf(constants.MsgImportDuplicateRow, firstRow)
+		} else {
+			imp.seen[key] = rowNumber
+		}
+	}
+
+	// Atribut divalidasi terhadap skema jenis barang seperti form barang:
+	// jika kolom attributes diisi, barang baru, atau jenis barang berubah
+	var currentAttributes map[string]string
+	typeChanged := false
+	if existing != nil {
+		currentAttributes = attributeValueMap(*existing)
+		typeChanged = itemType != nil && itemType.TypeID != existing.TypeID
+	}
+	if itemType != nil && (attributeInput != nil || existing == nil || typeChanged) {
+		values, err := imp.resolveAttributes(itemType, currentAttributes, attributeInput, result.Errors)
+		if err != nil {
+			return result, plan, err
+		}
+		plan.attributes = values
+	}
+
+	if len(result.Errors) > 0 {
+		return result, plan, nil
+	}
+
+	var sku *string
+	if result.SKU != "" {
+		sku = &result.SKU
+	}
+
+	if existing == nil {
+		plan.action = constants.ImportActionCreate
+		plan.item = models.Item{
+			ItemID:   uuid.New().String(),
+			TypeID:   itemType.TypeID,
+			UnitID:   unit.UnitID,
+			ItemName: result.ItemName,
+			SKU:      sku,
+			Stock:    0,
+		}
+		if minimumStock != nil {
+			plan.item.MinimumStock = *minimumStock
+		}
+		if requiresInspection != nil {
+			plan.item.RequiresInspection = *requiresInspection
+		}
+		for _, barcode := range barcodes {
+			barcode.ItemID = plan.item.ItemID
+			plan.addBarcodes = append(plan.addBarcodes, barcode)
+		}
+	} else {
+		plan.item = *existing
+		plan.item.ItemName = result.ItemName
+		plan.item.TypeID = itemType.TypeID
+		plan.item.UnitID = unit.UnitID
+		if sku != nil {
+			plan.item.SKU = sku
+		}
+		if minimumStock != nil {
+			plan.item.MinimumStock = *minimumStock
+		}
+		if requiresInspection != nil {
+			plan.item.RequiresInspection = *requiresInspection
+		}
+		if barcodes != nil {
+			plan.addBarcodes, plan.removeBarcodeIDs = diffImportBarcodes(existing.ItemID, existing.Barcodes, barcodes)
+		}
+		if plan.attributes != nil && sameAttributeValues(plan.attributes, *existing) {
+			plan.attributes = nil
+		}
+
+		plan.action = constants.ImportActionUnchanged
+		if plan.item.ItemName != existing.ItemName ||
+			!sameSKU(plan.item.SKU, existing.SKU) ||
+			plan.item.TypeID != existing.TypeID ||
+			plan.item.UnitID != existing.UnitID ||
+			plan.item.MinimumStock != existing.MinimumStock ||
+			plan.item.RequiresInspection != existing.RequiresInspection ||
+			len(plan.addBarcodes) > 0 || len(plan.removeBarcodeIDs) > 0 ||
+			plan.attributes != nil {
+			plan.action = constants.ImportActionUpdate
+		}
+	}
+
+	if plan.item.SKU != nil {
+		result.SKU = *plan.item.SKU
+	}
+	result.MinimumStock = plan.item.MinimumStock
+	result.RequiresInspection = plan.item.RequiresInspection
+	for _, barcode := range barcodes {
+		result.Barcodes = append(result.Barcodes, barcode.Code)
+	}
+	result.Action = plan.action
+	result.ItemID = plan.item.ItemID
+	return result, plan, nil
+}
+
+// resolveAttributes memvalidasi atribut satu baris dengan aturan yang sama
+// seperti form barang. Error ditulis ke rowErrors.
+func (imp *itemImporter) resolveAttributes(itemType *models.ItemType, current, input map[string]string, rowErrors map[string]string) (map[string]string, error) {
+	attributes, ok := imp.attributes[itemType.TypeID]
+	if !ok {
+		var err error
+		attributes, err = services.TypeAttributes(imp.db, itemType.TypeID)
+		if err != nil {
+			return nil, err
+		}
+		imp.attributes[itemType.TypeID] = attributes
+	}
+
+	values, validationErrors := services.ResolveAttributeValues(attributes, current, input)
+	for field, msg := range validationErrors {
+		rowErrors[field] = msg
+	}
+	return values, nil
+}
+
+func (imp *itemImporter) resolveType(name string, rowErrors map[string]string) (*models.ItemType, error) {
+	if name == "" {
+		rowErrors["item_type"] = constants.MsgFieldRequired
+		return nil, nil
+	}
+
+	key := strings.ToLower(name)
+	itemType, ok := imp.types[key]
+	if !ok {
+		var types []models.ItemType
+		if err := imp.db.Where("LOWER(type_name) = ?", key).Limit(1).Find(&types).Error; err != nil {
+			return nil, err
+		}
+		if len(types) == 1 {
+			itemType = &types[0]
+			imp.types[key] = itemType
+		}
+	}
+	if itemType != nil {
+		if itemType.ArchivedAt != nil {
+			rowErrors["item_type"] = constants.MsgItemTypeArchived
+			return nil, nil
+		}
+		return itemType, nil
+	}
+
+	if !imp.createMissing {
+		rowErrors["item_type"] = constants.MsgImportTypeNotFound
+		return nil, nil
+	}
+
+	itemType = &models.ItemType{
+		TypeID:      uuid.New().String(),
+		TypeName:    name,
+		StockPolicy: constants.StockPolicyStrict,
+	}
+	imp.types[key] = itemType
+	imp.newTypes = append(imp.newTypes, itemType)
+	// Jenis barang baru belum punya skema atribut
+	imp.attributes[itemType.TypeID] = nil
+	return itemType, nil
+}
+
+func (imp *itemImporter) resolveUnit(name string, rowErrors map[string]string) (*models.Unit, error) {
+	if name == "" {
+		rowErrors["unit"] = constants.MsgFieldRequired
+		return nil, nil
+	}
+
+	key := strings.ToLower(name)
+	unit, ok := imp.units[key]
+	if !ok {
+		var units []models.Unit
+		if err := imp.db.Where("LOWER(unit_name) = ?", key).Limit(1).Find(&units).Error; err != nil {
+			return nil, err
+		}
+		if len(units) == 1 {
+			unit = &units[0]
+			imp.units[key] = unit
+		}
+	}
+	if unit != nil {
+		if unit.ArchivedAt != nil {
+			rowErrors["unit"] = constants.MsgUnitArchived
+			return nil, nil
+		}
+		return unit, nil
+	}
+
+	if !imp.createMissing {
+		rowErrors["unit"] = constants.MsgImportUnitNotFound
+		return nil, nil
+	}
+
+	unit = &models.Unit{
+		UnitID:   uuid.New().String(),
+		UnitName: name,
+	}
+	imp.units[key] = unit
+	imp.newUnits = append(imp.newUnits, unit)
+	return unit, nil
+}
+
+func parseImportBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "1", "yes", "ya", "y":
+		return true, true
+	case "false", "0", "no", "tidak", "n":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func sameSKU(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// Barcode di file impor ditulis sebagai simbologi:kode dipisah titik koma,
+// kode tanpa simbologi dianggap custom
+func formatImportBarcodes(barcodes []models.ItemBarcode) string {
+	parts := make([]string, 0, len(barcodes))
+	for _, barcode := range barcodes {
+		parts = append(parts, barcode.Symbology+":"+barcode.Code)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func parseImportBarcodes(value string) ([]models.ItemBarcode, string) {
+	var barcodes []models.ItemBarcode
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		symbology, code := constants.SymbologyCustom, part
+		if prefix, rest, ok := strings.Cut(part, ":"); ok && isImportSymbology(strings.ToLower(prefix)) {
+			symbology, code = strings.ToLower(prefix), strings.TrimSpace(rest)
+		}
+		if !utils.IsValidBarcode(symbology, code) {
+			return nil, code + ": " + barcodeFormatMessage(symbology)
+		}
+		barcodes = append(barcodes, models.ItemBarcode{
+			BarcodeID: uuid.New().String(),
+			Code:      code,
+			Symbology: symbology,
+		})
+	}
+	return barcodes, ""
+}
+
+func isImportSymbology(value string) bool {
+	switch value {
+	case constants.SymbologyEAN13, constants.SymbologyCode128, constants.SymbologyCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+// diffImportBarcodes membandingkan barcode di file dengan barcode tersimpan.
+// Barcode yang tidak ada di file dihapus, barcode baru ditambahkan.
+func diffImportBarcodes(itemID string, current, wanted []models.ItemBarcode) ([]models.ItemBarcode, []string) {
+	currentKeys := make(map[string]bool, len(current))
+	for _, barcode := range current {
+		currentKeys[barcode.Symbology+":"+barcode.Code] = true
+	}
+	wantedKeys := make(map[string]bool, len(wanted))
+	var add []models.ItemBarcode
+	for _, barcode := range wanted {
+		key := barcode.Symbology + ":" + barcode.Code
+		wantedKeys[key] = true
+		if !currentKeys[key] {
+			barcode.ItemID = itemID
+			add = append(add, barcode)
+		}
+	}
+
+	var remove []string
+	for _, barcode := range current {
+		if !wantedKeys[barcode.Symbology+":"+barcode.Code] {
+			remove = append(remove, barcode.BarcodeID)
+		}
+	}
+	return add, remove
+}
+
+// Atribut di file impor memakai objek JSON yang sama dengan form barang
+func formatImportAttributes(item models.Item) string {
+	values := attributeValueMap(item)
+	if len(values) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// sameAttributeValues mengecek apakah nilai atribut per attribute_id sama
+// dengan atribut tersimpan untuk jenis barang saat ini
+func sameAttributeValues(values map[string]string, item models.Item) bool {
+	current := make(map[string]string, len(item.AttributeValues))
+	for _, value := range item.AttributeValues {
+		if value.Attribute.TypeID == item.TypeID {
+			current[value.AttributeID] = value.Value
+		}
+	}
+	if len(current) != len(values) {
+		return false
+	}
+	for attributeID, value := range values {
+		if current[attributeID] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+		&gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db, mock
+}
+
+func newTestImporter(db *gorm.DB) *itemImporter {
+	return &itemImporter{
+		db:         db,
+		types:      make(map[string]*models.ItemType),
+		attributes: make(map[string][]models.ItemTypeAttribute),
+		units:      make(map[string]*models.Unit),
+		seen:       make(map[string]int),
+	}
+}
+
+// expectImportLookups menyiapkan query jenis barang, satuan dan barang yang
+// sudah ada berdasarkan item_id
+func expectImportLookups(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM `item_types`").
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "type_name"}).AddRow("type-1", "Elektronik"))
+	mock.ExpectQuery("FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("FROM `items` WHERE item_id = ").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "type_id", "unit_id", "item_name", "sku", "minimum_stock", "requires_inspection"}).
+			AddRow("item-1", "type-1", "unit-1", "Kabel", "KBL-01", 5, true))
+	mock.ExpectQuery("FROM `item_attribute_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "attribute_id", "value"}))
+	mock.ExpectQuery("FROM `item_barcodes`").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "item_id", "code", "symbology"}).
+			AddRow("barcode-1", "item-1", "4006381333931", constants.SymbologyEAN13))
+}
+
+func TestValidateRowKeepsExistingValuesForBlankColumns(t *testing.T) {
+	header := []string{"item_id", "sku", "item_name", "item_type", "unit", "minimum_stock", "requires_inspection", "barcodes", "attributes"}
+	row := []string{"item-1", "", "Kabel", "Elektronik", "pcs", "", "", "", ""}
+
+	db, mock := newMockDB(t)
+	expectImportLookups(mock)
+
+	result, plan, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("validateRow() errors = %v", result.Errors)
+	}
+	if plan.action != constants.ImportActionUnchanged {
+		t.Errorf("action = %q, want %q", plan.action, constants.ImportActionUnchanged)
+	}
+	if plan.item.SKU == nil || *plan.item.SKU != "KBL-01" {
+		t.Errorf("SKU = %v, want KBL-01", plan.item.SKU)
+	}
+	if plan.item.MinimumStock != 5 || !plan.item.RequiresInspection {
+		t.Errorf("minimum_stock = %d, requires_inspection = %v, want 5, true",
+			plan.item.MinimumStock, plan.item.RequiresInspection)
+	}
+	if len(plan.addBarcodes) > 0 || len(plan.removeBarcodeIDs) > 0 || plan.attributes != nil {
+		t.Errorf("barcode dan atribut tidak boleh berubah, plan = %+v", plan)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateRowKeepsExistingValuesForMissingColumns(t *testing.T) {
+	header := []string{"item_id", "item_name", "item_type", "unit"}
+	row := []string{"item-1", "Kabel HDMI", "Elektronik", "pcs"}
+
+	db, mock := newMockDB(t)
+	expectImportLookups(mock)
+
+	result, plan, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("validateRow() errors = %v", result.Errors)
+	}
+	if plan.action != constants.ImportActionUpdate {
+		t.Errorf("action = %q, want %q", plan.action, constants.ImportActionUpdate)
+	}
+	if plan.item.SKU == nil || *plan.item.SKU != "KBL-01" || plan.item.MinimumStock != 5 || !plan.item.RequiresInspection {
+		t.Errorf("nilai lama harus dipertahankan, item = %+v", plan.item)
+	}
+	if result.SKU != "KBL-01" || result.MinimumStock != 5 || !result.RequiresInspection {
+		t.Errorf("hasil validasi harus menampilkan nilai lama, result = %+v", result)
+	}
+}
+
+func TestValidateRowReplacesBarcodes(t *testing.T) {
+	header := []string{"item_id", "item_name", "item_type", "unit", "barcodes"}
+	row := []string{"item-1", "Kabel", "Elektronik", "pcs", "code128:KBL-HDMI-2M"}
+
+	db, mock := newMockDB(t)
+	expectImportLookups(mock)
+	expectCodeUnused(mock)
+
+	result, plan, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("validateRow() errors = %v", result.Errors)
+	}
+	if plan.action != constants.ImportActionUpdate {
+		t.Errorf("action = %q, want %q", plan.action, constants.ImportActionUpdate)
+	}
+	if len(plan.addBarcodes) != 1 || plan.addBarcodes[0].Code != "KBL-HDMI-2M" || plan.addBarcodes[0].ItemID != "item-1" {
+		t.Errorf("addBarcodes = %+v", plan.addBarcodes)
+	}
+	if len(plan.removeBarcodeIDs) != 1 || plan.removeBarcodeIDs[0] != "barcode-1" {
+		t.Errorf("removeBarcodeIDs = %v, want [barcode-1]", plan.removeBarcodeIDs)
+	}
+}
+
+// expectCodeUnused menyiapkan pengecekan kode yang belum dipakai sebagai
+// barcode maupun SKU barang lain
+func expectCodeUnused(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM `item_barcodes` WHERE code = ").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "item_id", "code", "symbology"}))
+	mock.ExpectQuery("FROM `items` WHERE sku = ").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "sku"}))
+}
+
+func TestValidateRowRejectsSKUUsedAsBarcode(t *testing.T) {
+	header := []string{"item_id", "sku", "item_name", "item_type", "unit"}
+	row := []string{"item-1", "8991234567890", "Kabel", "Elektronik", "pcs"}
+
+	db, mock := newMockDB(t)
+	expectImportLookups(mock)
+	mock.ExpectQuery("FROM `item_barcodes` WHERE code = ").
+		WithArgs("8991234567890", "item-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "item_id", "code", "symbology"}).
+			AddRow("barcode-2", "item-2", "8991234567890", constants.SymbologyEAN13))
+
+	result, _, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	want := fmt.Sprintf(constants.MsgSKUUsedAsBarcode, "item-2")
+	if result.Errors["sku"] != want {
+		t.Errorf("errors[sku] = %q, want %q", result.Errors["sku"], want)
+	}
+}
+
+func TestValidateRowRejectsBarcodeUsedAsSKU(t *testing.T) {
+	header := []string{"item_id", "item_name", "item_type", "unit", "barcodes"}
+	row := []string{"item-1", "Kabel", "Elektronik", "pcs", "custom:SKU-LAIN"}
+
+	db, mock := newMockDB(t)
+	expectImportLookups(mock)
+	mock.ExpectQuery("FROM `item_barcodes` WHERE code = ").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "item_id", "code", "symbology"}))
+	mock.ExpectQuery("FROM `items` WHERE sku = ").
+		WithArgs("SKU-LAIN", "item-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "sku"}).AddRow("item-3", "SKU-LAIN"))
+
+	result, _, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	want := fmt.Sprintf(constants.MsgBarcodeUsedAsSKU, "item-3")
+	if result.Errors["barcodes"] != want {
+		t.Errorf("errors[barcodes] = %q, want %q", result.Errors["barcodes"], want)
+	}
+}
+
+func TestValidateRowRejectsCodeRepeatedInFile(t *testing.T) {
+	header := []string{"sku", "item_name", "item_type", "unit", "barcodes"}
+
+	db, mock := newMockDB(t)
+	mock.MatchExpectationsInOrder(false)
+	// Tidak ada barang maupun kode yang sudah tersimpan
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery("FROM `item_barcodes` WHERE code = ").
+			WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "item_id", "code", "symbology"}))
+	}
+	for i := 0; i < 6; i++ {
+		mock.ExpectQuery("FROM `items` WHERE").
+			WillReturnRows(sqlmock.NewRows([]string{"item_id", "sku"}))
+	}
+	mock.ExpectQuery("FROM `item_types`").
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "type_name"}).AddRow("type-1", "Elektronik"))
+	mock.ExpectQuery("FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("FROM `item_type_attributes`").
+		WillReturnRows(sqlmock.NewRows([]string{"attribute_id", "type_id", "name"}))
+
+	importer := newTestImporter(db)
+	// SKU boleh sama dengan barcode di baris yang sama
+	first, _, err := importer.validateRow(2, header, []string{"KODE-1", "Kabel", "Elektronik", "pcs", "KODE-1"})
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if len(first.Errors) > 0 {
+		t.Fatalf("baris pertama errors = %v", first.Errors)
+	}
+
+	second, _, err := importer.validateRow(3, header, []string{"", "Adaptor", "Elektronik", "pcs", "KODE-1"})
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	want := fmt.Sprintf(constants.MsgImportDuplicateRow, 2)
+	if second.Errors["barcodes"] != want {
+		t.Errorf("errors[barcodes] = %q, want %q", second.Errors["barcodes"], want)
+	}
+}
+
+func attributeRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"attribute_id", "type_id", "name", "label", "data_type", "options", "required", "sort_order"}).
+		AddRow("attr-color", "type-2", "warna", "Warna", constants.AttributeTypeEnum, `["hitam","putih"]`, true, 1).
+		AddRow("attr-length", "type-2", "panjang", "Panjang", constants.AttributeTypeNumber, "", false, 2)
+}
+
+func TestValidateRowValidatesAttributesOfNewItem(t *testing.T) {
+	header := []string{"item_name", "item_type", "unit"}
+	row := []string{"Kabel LAN", "Jaringan", "pcs"}
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("FROM `item_types`").
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "type_name"}).AddRow("type-2", "Jaringan"))
+	mock.ExpectQuery("FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("FROM `items` WHERE LOWER\\(item_name\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id"}))
+	mock.ExpectQuery("FROM `item_type_attributes`").WillReturnRows(attributeRows())
+
+	result, _, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if result.Errors["attributes.warna"] != constants.MsgItemAttributeRequired {
+		t.Errorf("errors = %v, want attributes.warna wajib diisi", result.Errors)
+	}
+}
+
+func TestValidateRowResolvesAttributesOfNewItem(t *testing.T) {
+	header := []string{"item_name", "item_type", "unit", "attributes"}
+	row := []string{"Kabel LAN", "Jaringan", "pcs", `{"warna": "hitam", "panjang": 2.50}`}
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("FROM `item_types`").
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "type_name"}).AddRow("type-2", "Jaringan"))
+	mock.ExpectQuery("FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("FROM `items` WHERE LOWER\\(item_name\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id"}))
+	mock.ExpectQuery("FROM `item_type_attributes`").WillReturnRows(attributeRows())
+
+	result, plan, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("validateRow() errors = %v", result.Errors)
+	}
+	if plan.attributes["attr-color"] != "hitam" || plan.attributes["attr-length"] != "2.5" {
+		t.Errorf("attributes = %v", plan.attributes)
+	}
+}
+
+func TestValidateRowValidatesAttributesOnTypeChange(t *testing.T) {
+	header := []string{"item_id", "item_name", "item_type", "unit"}
+	row := []string{"item-1", "Kabel", "Jaringan", "pcs"}
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("FROM `item_types`").
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "type_name"}).AddRow("type-2", "Jaringan"))
+	mock.ExpectQuery("FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("FROM `items` WHERE item_id = ").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "type_id", "unit_id", "item_name"}).
+			AddRow("item-1", "type-1", "unit-1", "Kabel"))
+	mock.ExpectQuery("FROM `item_attribute_values`").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "attribute_id", "value"}))
+	mock.ExpectQuery("FROM `item_barcodes`").
+		WillReturnRows(sqlmock.NewRows([]string{"barcode_id", "item_id"}))
+	mock.ExpectQuery("FROM `item_type_attributes`").WillReturnRows(attributeRows())
+
+	result, _, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	if result.Errors["attributes.warna"] != constants.MsgItemAttributeRequired {
+		t.Errorf("errors = %v, want attributes.warna wajib diisi", result.Errors)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateRowRejectsInvalidValues(t *testing.T) {
+	header := []string{"item_id", "item_name", "item_type", "unit", "minimum_stock", "requires_inspection", "barcodes", "attributes"}
+	row := []string{"item-1", "Kabel", "Elektronik", "pcs", "-1", "mungkin", "ean13:4006381333932", "[1]"}
+
+	db, mock := newMockDB(t)
+	expectImportLookups(mock)
+
+	result, _, err := newTestImporter(db).validateRow(2, header, row)
+	if err != nil {
+		t.Fatalf("validateRow() error = %v", err)
+	}
+	for _, field := range []string{"minimum_stock", "requires_inspection", "barcodes", "attributes"} {
+		if result.Errors[field] == "" {
+			t.Errorf("field %s harus error, errors = %v", field, result.Errors)
+		}
+	}
+}
+
+func TestParseImportBarcodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []models.ItemBarcode
+		wantErr bool
+	}{
+		{
+			name:  "dengan simbologi",
+			value: "ean13:4006381333931; code128:ABC 123",
+			want: []models.ItemBarcode{
+				{Code: "4006381333931", Symbology: constants.SymbologyEAN13},
+				{Code: "ABC 123", Symbology: constants.SymbologyCode128},
+			},
+		},
+		{
+			name:  "tanpa simbologi dianggap custom",
+			value: "RAK-01/A;",
+			want:  []models.ItemBarcode{{Code: "RAK-01/A", Symbology: constants.SymbologyCustom}},
+		},
+		{
+			name:    "digit pemeriksa EAN-13 salah",
+			value:   "ean13:4006381333932",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := parseImportBarcodes(tt.value)
+			if tt.wantErr {
+				if msg == "" {
+					t.Fatalf("parseImportBarcodes() msg kosong, want error")
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("parseImportBarcodes() msg = %q", msg)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseImportBarcodes() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Code != tt.want[i].Code || got[i].Symbology != tt.want[i].Symbology {
+					t.Errorf("barcode %d = %s:%s, want %s:%s", i,
+						got[i].Symbology, got[i].Code, tt.want[i].Symbology, tt.want[i].Code)
+				}
+			}
+		})
+	}
+}
+
+func TestImportBarcodesRoundTrip(t *testing.T) {
+	barcodes := []models.ItemBarcode{
+		{Code: "4006381333931", Symbology: constants.SymbologyEAN13},
+		{Code: "RAK-01", Symbology: constants.SymbologyCustom},
+	}
+
+	parsed, msg := parseImportBarcodes(formatImportBarcodes(barcodes))
+	if msg != "" {
+		t.Fatalf("parseImportBarcodes() msg = %q", msg)
+	}
+	add, remove := diffImportBarcodes("item-1", barcodes, parsed)
+	if len(add) > 0 || len(remove) > 0 {
+		t.Fatalf("hasil ekspor harus diimpor tanpa perubahan, add = %+v, remove = %v", add, remove)
+	}
+}
+
+func TestParseImportBool(t *testing.T) {
+	for _, value := range []string{"true", "YA", "1", "y"} {
+		if got, ok := parseImportBool(value); !ok || !got {
+			t.Errorf("parseImportBool(%q) = %v, %v, want true, true", value, got, ok)
+		}
+	}
+	for _, value := range []string{"false", "Tidak", "0", "n"} {
+		if got, ok := parseImportBool(value); !ok || got {
+			t.Errorf("parseImportBool(%q) = %v, %v, want false, true", value, got, ok)
+		}
+	}
+	if _, ok := parseImportBool("mungkin"); ok {
+		t.Error("parseImportBool(\"mungkin\") harus tidak valid")
+	}
+}
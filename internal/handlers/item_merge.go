@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errMergeClosedPeriod membatalkan penggabungan jika ada transaksi item sumber
+// di periode tertutup
+var errMergeClosedPeriod = errors.New("merge_closed_period")
+
+type itemCountRow struct {
+	ItemID string
+	Total  int64
+}
+
+// MergeItems memindahkan riwayat item sumber ke item tujuan, menghitung ulang
+// stok, lalu mengarsipkan item sumber. dry_run hanya mengembalikan pratinjau.
+func (h *ItemHandler) MergeItems(c *gin.Context) {
+	var req dto.MergeItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var sourceIDs []string
+	seen := make(map[string]bool)
+	for _, id := range req.SourceItemIDs {
+		if id == req.TargetItemID {
+			utils.BadRequest(c, constants.MsgItemMergeInvalid, gin.H{
+				"source_item_ids": constants.MsgItemMergeSelf,
+			})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+	allIDs := append([]string{req.TargetItemID}, sourceIDs...)
+
+	var target models.Item
+	if err := h.DB.Preload("Unit").First(&target, "item_id = ?", req.TargetItemID).Error; err != nil {
+		utils.NotFound(c, constants.MsgItemNotFound)
+		return
+	}
+	if !ensureItemActive(c, target) {
+		return
+	}
+
+	var sources []models.Item
+	if err := h.DB.Preload("Unit").
+		Where("item_id IN ?", sourceIDs).
+		Order("item_name ASC").
+		Find(&sources).Error; err != nil {
+		utils.ServerError(c, constants.MsgFailedFetchItems, err)
+		return
+	}
+	if len(sources) != len(sourceIDs) {
+		found := make(map[string]bool, len(sources))
+		for _, source := range sources {
+			found[source.ItemID] = true
+		}
+		var missing []string
+		for _, id := range sourceIDs {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		utils.Error(c, http.StatusNotFound, constants.MsgItemNotFound, gin.H{
+			"source_item_ids": fmt.Sprintf(constants.MsgLabelItemsNotFound, strings.Join(missing, ", ")),
+		})
+		return
+	}
+
+	// Jumlah hanya bisa dijumlahkan jika satuannya sama
+	for _, source := range sources {
+		if source.UnitID != target.UnitID {
+			utils.BadRequest(c, constants.MsgItemMergeInvalid, gin.H{
+				"source_item_ids": fmt.Sprintf(constants.MsgItemMergeUnitMismatch, source.ItemName, target.Unit.UnitName),
+			})
+			return
+		}
+	}
+
+	transactionCounts, err := countByItem(h.DB, &models.Transaction{}, allIDs)
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemMergeFailed, err)
+		return
+	}
+	barcodeCounts, err := countByItem(h.DB, &models.ItemBarcode{}, allIDs)
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemMergeFailed, err)
+		return
+	}
+
+	closedCount, err := countClosedPeriodTransactions(h.DB, sourceIDs)
+	if err != nil {
+		utils.ServerError(c, constants.MsgPeriodCheckFailed, err)
+		return
+	}
+
+	// Stok hasil dihitung dari gabungan riwayat transaksi
+	expected, err := services.ExpectedStockFor(h.DB, allIDs)
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemMergeFailed, err)
+		return
+	}
+
+	resp := dto.MergeItemsResponse{
+		DryRun:                   req.DryRun,
+		Target:                   toMergeItemSummary(target, transactionCounts, barcodeCounts),
+		ClosedPeriodTransactions: closedCount,
+	}
+	for _, id := range allIDs {
+		resp.ResultingStock += expected[id].ExpectedStock
+		resp.ResultingQuarantineStock += expected[id].ExpectedQuarantine
+	}
+	for _, source := range sources {
+		resp.Sources = append(resp.Sources, toMergeItemSummary(source, transactionCounts, barcodeCounts))
+		resp.TransactionsMoved += transactionCounts[source.ItemID]
+		resp.BarcodesMoved += barcodeCounts[source.ItemID]
+		if source.SKU != nil {
+			resp.BarcodesMoved++
+		}
+	}
+
+	if req.DryRun {
+		utils.Success(c, http.StatusOK, constants.MsgItemMergePreview, resp)
+		return
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		var locked []models.Item
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("item_id IN ?", allIDs).
+			Order("item_id").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+
+		// Dicek ulang setelah item dikunci karena periode bisa ditutup
+		// setelah pratinjau
+		count, err := countClosedPeriodTransactions(tx, sourceIDs)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			closedCount = count
+			return errMergeClosedPeriod
+		}
+
+		moved := tx.Model(&models.Transaction{}).
+			Where("item_id IN ?", sourceIDs).
+			Update("item_id", target.ItemID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		resp.TransactionsMoved = moved.RowsAffected
+
+		if err := tx.Model(&models.Inspection{}).
+			Where("item_id IN ?", sourceIDs).
+			Update("item_id", target.ItemID).Error; err != nil {
+			return err
+		}
+
+		barcodes := tx.Model(&models.ItemBarcode{}).
+			Where("item_id IN ?", sourceIDs).
+			Update("item_id", target.ItemID)
+		if barcodes.Error != nil {
+			return barcodes.Error
+		}
+		resp.BarcodesMoved = barcodes.RowsAffected
+
+		// Galeri item sumber dipindah tanpa status gambar utama
+		images := tx.Model(&models.ItemImage{}).
+			Where("item_id IN ?", sourceIDs).
+			Updates(map[string]interface{}{
+				"item_id":    target.ItemID,
+				"is_primary": false,
+			})
+		if images.Error != nil {
+			return images.Error
+		}
+
+		// SKU item sumber tetap bisa di-scan sebagai barcode item tujuan
+		image := target.Image
+		for _, source := range sources {
+			if source.SKU != nil {
+				if err := tx.Model(&models.Item{}).
+					Where("item_id = ?", source.ItemID).
+					Update("sku", nil).Error; err != nil {
+					return err
+				}
+				var taken int64
+				if err := tx.Model(&models.ItemBarcode{}).Where("code = ?", *source.SKU).Count(&taken).Error; err != nil {
+					return err
+				}
+				if taken == 0 {
+					if err := tx.Create(&models.ItemBarcode{
+						BarcodeID: uuid.New().String(),
+						ItemID:    target.ItemID,
+						Code:      *source.SKU,
+						Symbology: constants.SymbologyCustom,
+					}).Error; err != nil {
+						return err
+					}
+					resp.BarcodesMoved++
+				}
+			}
+			if image == "" && source.Image != "" {
+				image = source.Image
+			}
+		}
+		if image != target.Image {
+			if images.RowsAffected > 0 {
+				if err := services.SyncPrimaryImage(tx, target.ItemID); err != nil {
+					return err
+				}
+			} else if err := tx.Model(&models.Item{}).
+				Where("item_id = ?", target.ItemID).
+				Update("image", image).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := mergeBOMReferences(tx, target.ItemID, sourceIDs); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Item{}).
+			Where("item_id IN ? AND archived_at IS NULL", sourceIDs).
+			Update("archived_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		// Trigger memproses baris satu per satu, jadi stok dihitung ulang dari
+		// riwayat agar hasilnya tidak bergantung pada urutan update
+		if err := services.RecomputeStock(tx, allIDs); err != nil {
+			return err
+		}
+
+		var sourceNames []string
+		for _, source := range sources {
+			sourceNames = append(sourceNames, source.ItemName)
+		}
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionItemMerge,
+			constants.AuditEntityItem, target.ItemID, gin.H{
+				"target_item_name":   target.ItemName,
+				"source_item_ids":    sourceIDs,
+				"source_item_names":  sourceNames,
+				"transactions_moved": resp.TransactionsMoved,
+				"barcodes_moved":     resp.BarcodesMoved,
+				"resulting_stock":    resp.ResultingStock,
+			})
+	})
+	if errors.Is(err, errMergeClosedPeriod) {
+		utils.Error(c, http.StatusConflict, constants.MsgPeriodLocked, gin.H{
+			"error_code":      constants.ErrCodePeriodClosed,
+			"source_item_ids": fmt.Sprintf(constants.MsgItemMergeClosedPeriod, closedCount),
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgItemMergeFailed, err)
+		return
+	}
+
+	var merged models.Item
+	if err := h.DB.Preload("Unit").First(&merged, "item_id = ?", target.ItemID).Error; err != nil {
+		utils.ServerError(c, constants.MsgItemMergeFailed, err)
+		return
+	}
+	transactionCounts[target.ItemID] += resp.TransactionsMoved
+	barcodeCounts[target.ItemID] += resp.BarcodesMoved
+
+	resp.Committed = true
+	resp.Target = toMergeItemSummary(merged, transactionCounts, barcodeCounts)
+	resp.ResultingStock = merged.Stock
+	resp.ResultingQuarantineStock = merged.QuarantineStock
+	utils.Success(c, http.StatusOK, constants.MsgItemMergedSuccess, resp)
+}
+
+// countClosedPeriodTransactions menghitung transaksi item sumber di periode
+// tertutup. Memindahkan transaksi tersebut akan mengubah laporan yang sudah
+// dikunci.
+func countClosedPeriodTransactions(db *gorm.DB, sourceIDs []string) (int64, error) {
+	var count int64
+	err := db.Model(&models.Transaction{}).
+		Joins("JOIN closed_periods p ON transactions.date BETWEEN p.start_date AND p.end_date AND p.status = ?", constants.PeriodStatusClosed).
+		Where("transactions.item_id IN ?", sourceIDs).
+		Count(&count).Error
+	return count, err
+}
+
+// mergeBOMReferences mengarahkan komponen kit dari item sumber ke item tujuan.
+// BOM milik item sumber dipindahkan hanya jika item tujuan belum punya BOM.
+func mergeBOMReferences(tx *gorm.DB, targetID string, sourceIDs []string) error {
+	var usages []models.BOMComponent
+	if err := tx.Where("component_item_id IN ?", sourceIDs).Find(&usages).Error; err != nil {
+		return err
+	}
+	for _, usage := range usages {
+		if usage.KitItemID == targetID {
+			if err := tx.Delete(&usage).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		var existing []models.BOMComponent
+		if err := tx.Where("kit_item_id = ? AND component_item_id = ?", usage.KitItemID, targetID).
+			Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+		if len(existing) == 1 {
+			if err := tx.Model(&existing[0]).
+				Update("quantity", existing[0].Quantity+usage.Quantity).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&usage).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tx.Model(&usage).Update("component_item_id", targetID).Error; err != nil {
+			return err
+		}
+	}
+
+	var targetComponents int64
+	if err := tx.Model(&models.BOMComponent{}).Where("kit_item_id = ?", targetID).Count(&targetComponents).Error; err != nil {
+		return err
+	}
+	if targetComponents > 0 {
+		return nil
+	}
+
+	for _, sourceID := range sourceIDs {
+		var components []models.BOMComponent
+		if err := tx.Where("kit_item_id = ? AND component_item_id <> ?", sourceID, targetID).
+			Find(&components).Error; err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			continue
+		}
+		return tx.Model(&models.BOMComponent{}).
+			Where("kit_item_id = ? AND component_item_id <> ?", sourceID, targetID).
+			Update("kit_item_id", targetID).Error
+	}
+	return nil
+}
+
+func countByItem(db *gorm.DB, model interface{}, itemIDs []string) (map[string]int64, error) {
+	var rows []itemCountRow
+	if err := db.Model(model).
+		Select("item_id, COUNT(*) AS total").
+		Where("item_id IN ?", itemIDs).
+		Group("item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ItemID] = row.Total
+	}
+	return counts, nil
+}
+
+func toMergeItemSummary(item models.Item, transactionCounts, barcodeCounts map[string]int64) dto.MergeItemSummary {
+	summary := dto.MergeItemSummary{
+		ItemID:          item.ItemID,
+		ItemName:        item.ItemName,
+		UnitName:        item.Unit.UnitName,
+		Stock:           item.Stock,
+		QuarantineStock: item.QuarantineStock,
+		Transactions:    transactionCounts[item.ItemID],
+		Barcodes:        barcodeCounts[item.ItemID],
+	}
+	if item.SKU != nil {
+		summary.SKU = *item.SKU
+	}
+	return summary
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mergeTargetID = "11111111-1111-1111-1111-111111111111"
+	mergeSourceID = "22222222-2222-2222-2222-222222222222"
+)
+
+func TestMergeItemsRechecksClosedPeriodInTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, mock := newMockDB(t)
+
+	itemColumns := []string{"item_id", "item_name", "unit_id"}
+	mock.ExpectQuery("SELECT \\* FROM `items` WHERE item_id = ").
+		WillReturnRows(sqlmock.NewRows(itemColumns).AddRow(mergeTargetID, "Kabel HDMI", "unit-1"))
+	mock.ExpectQuery("SELECT \\* FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("SELECT \\* FROM `items` WHERE item_id IN .* ORDER BY item_name ASC").
+		WillReturnRows(sqlmock.NewRows(itemColumns).AddRow(mergeSourceID, "Kabel HDMI 2m", "unit-1"))
+	mock.ExpectQuery("SELECT \\* FROM `units`").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "unit_name"}).AddRow("unit-1", "pcs"))
+	mock.ExpectQuery("FROM `transactions` WHERE item_id IN .* GROUP BY").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "total"}))
+	mock.ExpectQuery("FROM `item_barcodes` WHERE item_id IN .* GROUP BY").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "total"}))
+	// Saat pratinjau belum ada periode tertutup
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `transactions` JOIN closed_periods").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("WHERE e.item_id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id"}))
+
+	// Periode ditutup sebelum item dikunci
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `items` WHERE item_id IN .* ORDER BY item_id FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(mergeTargetID, "Kabel HDMI", "unit-1").
+			AddRow(mergeSourceID, "Kabel HDMI 2m", "unit-1"))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `transactions` JOIN closed_periods").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "user-1")
+	c.Request = httptest.NewRequest(http.MethodPost, "/items/merge", strings.NewReader(
+		`{"target_item_id":"`+mergeTargetID+`","source_item_ids":["`+mergeSourceID+`"]}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h := &ItemHandler{DB: db}
+	h.MergeItems(c)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusConflict, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), constants.ErrCodePeriodClosed) {
+		t.Errorf("body = %s, want error_code %s", w.Body.String(), constants.ErrCodePeriodClosed)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,19 +1,25 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	constant "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var errInvalidParent = errors.New("invalid_parent")
+
 type ItemTypeHandler struct {
 	DB *gorm.DB
 }
@@ -29,18 +35,43 @@ func (h *ItemTypeHandler) CreateItemType(c *gin.Context) {
 	// Cek duplikat type name
 	var existingType models.ItemType
 	if err := h.DB.Where("type_name = ?", req.TypeName).First(&existingType).Error; err == nil {
+		detail := constant.MsgItemTypeExistsDetail
+		if existingType.ArchivedAt != nil {
+			detail = constant.MsgItemTypeExistsArchived
+		}
 		utils.Error(c, http.StatusConflict, constant.MsgItemTypeExists, gin.H{
-			"type_name": constant.MsgItemTypeExistsDetail,
+			"type_name": detail,
 		})
 		return
 	}
 
+	// Validasi jenis barang induk
+	var parentID *string
+	if req.ParentID != "" {
+		var parent models.ItemType
+		if err := h.DB.Where("type_id = ?", req.ParentID).First(&parent).Error; err != nil {
+			utils.BadRequest(c, constant.MsgInvalidItemType, gin.H{
+				"parent_id": constant.MsgItemTypeParentNotFound,
+			})
+			return
+		}
+		if parent.ArchivedAt != nil {
+			utils.BadRequest(c, constant.MsgInvalidItemType, gin.H{
+				"parent_id": constant.MsgItemTypeParentArchived,
+			})
+			return
+		}
+		parentID = &parent.TypeID
+	}
+
 	// Buat item type baru
 	newItemType := models.ItemType{
-		TypeID:    uuid.New().String(),
-		TypeName:  req.TypeName,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		TypeID:      uuid.New().String(),
+		ParentID:    parentID,
+		TypeName:    req.TypeName,
+		StockPolicy: constant.StockPolicyStrict,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	if err := h.DB.Create(&newItemType).Error; err != nil {
@@ -48,10 +79,7 @@ func (h *ItemTypeHandler) CreateItemType(c *gin.Context) {
 		return
 	}
 
-	resp := dto.ItemTypeResponse{
-		TypeID:   newItemType.TypeID,
-		TypeName: newItemType.TypeName,
-	}
+	resp := h.withPath(toItemTypeResponse(newItemType))
 
 	utils.Success(c, http.StatusCreated, constant.MsgItemTypeCreatedSuccess, resp)
 }
@@ -85,19 +113,79 @@ func (h *ItemTypeHandler) UpdateItemType(c *gin.Context) {
 	itemType.TypeName = req.TypeName
 	itemType.UpdatedAt = time.Now()
 
-	if err := h.DB.Save(&itemType).Error; err != nil {
+	// Pindah induk dicek di dalam transaksi dengan seluruh jenis barang
+	// dikunci agar dua perpindahan bersamaan tidak membentuk siklus
+	var parentError string
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if req.ParentID != nil {
+			itemType.ParentID = nil
+			if *req.ParentID != "" {
+				tree, err := services.LoadTypeTree(tx.Clauses(clause.Locking{Strength: "UPDATE"}))
+				if err != nil {
+					return err
+				}
+				parent, ok := tree.Get(*req.ParentID)
+				switch {
+				case !ok:
+					parentError = constant.MsgItemTypeParentNotFound
+				case tree.IsDescendant(itemType.TypeID, parent.TypeID):
+					parentError = constant.MsgItemTypeParentCycle
+				case parent.ArchivedAt != nil:
+					parentError = constant.MsgItemTypeParentArchived
+				}
+				if parentError != "" {
+					return errInvalidParent
+				}
+				itemType.ParentID = &parent.TypeID
+			}
+		}
+
+		return tx.Save(&itemType).Error
+	})
+	if errors.Is(err, errInvalidParent) {
+		utils.BadRequest(c, constant.MsgInvalidItemType, gin.H{
+			"parent_id": parentError,
+		})
+		return
+	}
+	if err != nil {
 		utils.ServerError(c, constant.MsgItemTypeUpdateFailed, err)
 		return
 	}
 
-	resp := dto.ItemTypeResponse{
-		TypeID:   itemType.TypeID,
-		TypeName: itemType.TypeName,
-	}
+	resp := h.withPath(toItemTypeResponse(itemType))
 
 	utils.Success(c, http.StatusOK, constant.MsgItemTypeUpdatedSuccess, resp)
 }
 
+func (h *ItemTypeHandler) UpdateStockPolicy(c *gin.Context) {
+	typeID := c.Param("id")
+
+	var req dto.UpdateStockPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.MsgValidationFailed, err)
+		return
+	}
+
+	var itemType models.ItemType
+	if err := h.DB.Where("type_id = ?", typeID).First(&itemType).Error; err != nil {
+		utils.NotFound(c, constant.MsgItemTypeNotFound)
+		return
+	}
+
+	itemType.StockPolicy = req.StockPolicy
+	itemType.UpdatedAt = time.Now()
+
+	if err := h.DB.Save(&itemType).Error; err != nil {
+		utils.ServerError(c, constant.MsgItemTypeUpdateFailed, err)
+		return
+	}
+
+	resp := h.withPath(toItemTypeResponse(itemType))
+
+	utils.Success(c, http.StatusOK, constant.MsgStockPolicyUpdated, resp)
+}
+
 func (h *ItemTypeHandler) GetAllItemTypes(c *gin.Context) {
 	var req dto.ItemTypeListRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
@@ -114,14 +202,36 @@ func (h *ItemTypeHandler) GetAllItemTypes(c *gin.Context) {
 	}
 	offset := (req.Page - 1) * req.Limit
 
+	tree, err := services.LoadTypeTree(h.DB)
+	if err != nil {
+		utils.ServerError(c, constant.MsgInternalServerError, err)
+		return
+	}
+
+	// Tampilan pohon tidak dipaginasi, induk dari jenis yang cocok ikut ditampilkan
+	if req.View == "tree" {
+		search := strings.ToLower(req.Search)
+		nodes := buildTypeTree(tree, tree.Roots(), func(itemType models.ItemType) bool {
+			return matchArchived(itemType.ArchivedAt, req.Archived) &&
+				strings.Contains(strings.ToLower(itemType.TypeName), search)
+		})
+		utils.Success(c, http.StatusOK, constant.MsgItemTypeTreeSuccess, nodes)
+		return
+	}
+
 	// Build query
 	query := h.DB.Model(&models.ItemType{}).Order("created_at DESC")
+	query = filterArchived(query, req.Archived, "archived_at")
 
 	// Add search filter
 	if req.Search != "" {
 		query = query.Where("type_name LIKE ?", "%"+req.Search+"%")
 	}
 
+	if req.ParentID != "" {
+		query = query.Where("parent_id = ?", req.ParentID)
+	}
+
 	// Get total data
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -139,10 +249,9 @@ func (h *ItemTypeHandler) GetAllItemTypes(c *gin.Context) {
 	// Map to response
 	var typeResponses []dto.ItemTypeResponse
 	for _, itemType := range itemTypes {
-		typeResponses = append(typeResponses, dto.ItemTypeResponse{
-			TypeID:   itemType.TypeID,
-			TypeName: itemType.TypeName,
-		})
+		resp := toItemTypeResponse(itemType)
+		resp.Path = typePath(tree, itemType.TypeID)
+		typeResponses = append(typeResponses, resp)
 	}
 
 	// Calculate pagination
@@ -164,9 +273,25 @@ func (h *ItemTypeHandler) GetAllItemTypes(c *gin.Context) {
 	utils.Success(c, http.StatusOK, constant.MsgItemTypesFetchSuccess, resp)
 }
 
+// DeleteItemType mengarsipkan jenis barang. Barang yang sudah memakai jenis
+// ini tidak berubah, tetapi barang baru tidak bisa memilihnya.
 func (h *ItemTypeHandler) DeleteItemType(c *gin.Context) {
+	h.setItemTypeArchived(c, true)
+}
+
+func (h *ItemTypeHandler) RestoreItemType(c *gin.Context) {
+	h.setItemTypeArchived(c, false)
+}
+
+func (h *ItemTypeHandler) setItemTypeArchived(c *gin.Context, archive bool) {
 	typeID := c.Param("id")
 
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
 	// Cek apakah type ada
 	var itemType models.ItemType
 	if err := h.DB.Where("type_id = ?", typeID).First(&itemType).Error; err != nil {
@@ -174,25 +299,119 @@ func (h *ItemTypeHandler) DeleteItemType(c *gin.Context) {
 		return
 	}
 
-	// Cek apakah type digunakan di items
-	var count int64
-	if err := h.DB.Model(&models.Item{}).Where("type_id = ?", typeID).Count(&count).Error; err != nil {
-		utils.ServerError(c, constant.MsgItemTypeDeleteFailed, err)
+	action, message := constant.AuditActionItemTypeArchive, constant.MsgItemTypeArchivedOK
+	var archivedAt *time.Time
+	if archive {
+		if itemType.ArchivedAt != nil {
+			utils.Error(c, http.StatusConflict, constant.MsgItemTypeArchived, gin.H{
+				"type_id": constant.MsgItemTypeArchived,
+			})
+			return
+		}
+		// Sub-jenis aktif harus diarsipkan terlebih dahulu
+		var activeChildren int64
+		if err := h.DB.Model(&models.ItemType{}).
+			Where("parent_id = ? AND archived_at IS NULL", itemType.TypeID).
+			Count(&activeChildren).Error; err != nil {
+			utils.ServerError(c, constant.MsgItemTypeUpdateFailed, err)
+			return
+		}
+		if activeChildren > 0 {
+			utils.Error(c, http.StatusConflict, constant.MsgItemTypeArchived, gin.H{
+				"type_id": fmt.Sprintf(constant.MsgItemTypeHasChildren, activeChildren),
+			})
+			return
+		}
+		now := time.Now()
+		archivedAt = &now
+	} else {
+		if itemType.ArchivedAt == nil {
+			utils.Error(c, http.StatusConflict, constant.MsgItemTypeNotArchived, gin.H{
+				"type_id": constant.MsgItemTypeNotArchived,
+			})
+			return
+		}
+		if itemType.ParentID != nil {
+			var parent models.ItemType
+			if err := h.DB.Where("type_id = ?", *itemType.ParentID).First(&parent).Error; err == nil && parent.ArchivedAt != nil {
+				utils.Error(c, http.StatusConflict, constant.MsgItemTypeArchived, gin.H{
+					"parent_id": constant.MsgItemTypeParentArchived,
+				})
+				return
+			}
+		}
+		action, message = constant.AuditActionItemTypeRestore, constant.MsgItemTypeRestoredOK
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ItemType{}).
+			Where("type_id = ?", itemType.TypeID).
+			Update("archived_at", archivedAt).Error; err != nil {
+			return err
+		}
+
+		return services.RecordAudit(tx, userID.(string), action,
+			constant.AuditEntityItemType, itemType.TypeID, gin.H{
+				"type_name": itemType.TypeName,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constant.MsgItemTypeUpdateFailed, err)
 		return
 	}
 
-	if count > 0 {
-		utils.Error(c, http.StatusConflict, constant.MsgItemTypeInUse, gin.H{
-			"type_id": fmt.Sprintf(constant.MsgItemTypeInUseDetail, count),
+	itemType.ArchivedAt = archivedAt
+	utils.Success(c, http.StatusOK, message, h.withPath(toItemTypeResponse(itemType)))
+}
+
+// withPath melengkapi response dengan jalur kategori dari akar
+func (h *ItemTypeHandler) withPath(resp dto.ItemTypeResponse) dto.ItemTypeResponse {
+	if tree, err := services.LoadTypeTree(h.DB); err == nil {
+		resp.Path = typePath(tree, resp.TypeID)
+	}
+	return resp
+}
+
+func typePath(tree *services.TypeTree, typeID string) []dto.ItemTypeBreadcrumb {
+	var path []dto.ItemTypeBreadcrumb
+	for _, itemType := range tree.Path(typeID) {
+		path = append(path, dto.ItemTypeBreadcrumb{
+			TypeID:   itemType.TypeID,
+			TypeName: itemType.TypeName,
 		})
-		return
 	}
+	return path
+}
 
-	// Hapus type
-	if err := h.DB.Delete(&itemType).Error; err != nil {
-		utils.ServerError(c, constant.MsgItemTypeDeleteFailed, err)
-		return
+// buildTypeTree menyusun pohon jenis barang. Node yang tidak cocok tetap
+// ditampilkan jika salah satu turunannya cocok.
+func buildTypeTree(tree *services.TypeTree, ids []string, match func(models.ItemType) bool) []dto.ItemTypeTreeNode {
+	nodes := []dto.ItemTypeTreeNode{}
+	for _, id := range ids {
+		itemType, _ := tree.Get(id)
+		children := buildTypeTree(tree, tree.Children(id), match)
+		if len(children) == 0 && !match(itemType) {
+			continue
+		}
+		nodes = append(nodes, dto.ItemTypeTreeNode{
+			ItemTypeResponse: toItemTypeResponse(itemType),
+			Children:         children,
+		})
+	}
+	return nodes
+}
+
+func toItemTypeResponse(itemType models.ItemType) dto.ItemTypeResponse {
+	var parentID string
+	if itemType.ParentID != nil {
+		parentID = *itemType.ParentID
 	}
 
-	utils.Success(c, http.StatusOK, constant.MsgItemTypeDeletedSuccess, nil)
+	return dto.ItemTypeResponse{
+		TypeID:      itemType.TypeID,
+		TypeName:    itemType.TypeName,
+		ParentID:    parentID,
+		StockPolicy: itemType.StockPolicy,
+		ArchivedAt:  formatArchivedAt(itemType.ArchivedAt),
+	}
 }
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	constant "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func (h *ItemTypeHandler) GetItemTypeAttributes(c *gin.Context) {
+	typeID := c.Param("id")
+
+	var itemType models.ItemType
+	if err := h.DB.Where("type_id = ?", typeID).First(&itemType).Error; err != nil {
+		utils.NotFound(c, constant.MsgItemTypeNotFound)
+		return
+	}
+
+	attributes, err := services.TypeAttributes(h.DB, itemType.TypeID)
+	if err != nil {
+		utils.ServerError(c, constant.MsgInternalServerError, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constant.MsgAttributesFetchSuccess, toAttributeResponses(attributes))
+}
+
+// SetItemTypeAttributes mengganti skema atribut jenis barang. Atribut
+// dicocokkan lewat nama sehingga nilai yang sudah tersimpan di barang tetap
+// ada; atribut yang tidak dikirim dihapus beserta nilainya.
+func (h *ItemTypeHandler) SetItemTypeAttributes(c *gin.Context) {
+	typeID := c.Param("id")
+
+	var req dto.SetItemTypeAttributesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
+	var itemType models.ItemType
+	if err := h.DB.Where("type_id = ?", typeID).First(&itemType).Error; err != nil {
+		utils.NotFound(c, constant.MsgItemTypeNotFound)
+		return
+	}
+
+	existing, err := services.TypeAttributes(h.DB, itemType.TypeID)
+	if err != nil {
+		utils.ServerError(c, constant.MsgAttributesUpdateFailed, err)
+		return
+	}
+	existingByName := make(map[string]models.ItemTypeAttribute, len(existing))
+	for _, attribute := range existing {
+		existingByName[attribute.Name] = attribute
+	}
+
+	validationErrors := gin.H{}
+	seen := make(map[string]int)
+	var attributes []models.ItemTypeAttribute
+	for i, input := range req.Attributes {
+		field := fmt.Sprintf("attributes[%d]", i)
+
+		if !attributeNamePattern.MatchString(input.Name) {
+			validationErrors[field+".name"] = constant.MsgAttributeNameInvalid
+			continue
+		}
+		if first, ok := seen[input.Name]; ok {
+			validationErrors[field+".name"] = fmt.Sprintf(constant.MsgAttributeNameDuplicate, first+1)
+			continue
+		}
+		seen[input.Name] = i
+
+		// Opsi hanya berlaku untuk atribut enum
+		var options string
+		if input.DataType == constant.AttributeTypeEnum {
+			if len(input.Options) == 0 {
+				validationErrors[field+".options"] = constant.MsgAttributeOptionsRequired
+				continue
+			}
+			unique := make(map[string]bool)
+			duplicate := ""
+			for _, option := range input.Options {
+				if unique[option] {
+					duplicate = option
+					break
+				}
+				unique[option] = true
+			}
+			if duplicate != "" {
+				validationErrors[field+".options"] = fmt.Sprintf(constant.MsgAttributeOptionDuplicate, duplicate)
+				continue
+			}
+			encoded, _ := json.Marshal(input.Options)
+			options = string(encoded)
+		}
+
+		attribute := models.ItemTypeAttribute{
+			AttributeID: uuid.New().String(),
+			TypeID:      itemType.TypeID,
+			Name:        input.Name,
+			Label:       input.Label,
+			DataType:    input.DataType,
+			Options:     options,
+			Required:    input.Required,
+			SortOrder:   i,
+		}
+
+		if current, ok := existingByName[input.Name]; ok {
+			attribute.AttributeID = current.AttributeID
+			attribute.CreatedAt = current.CreatedAt
+
+			// Nilai yang sudah tersimpan harus tetap valid dengan skema baru
+			if current.DataType != input.DataType {
+				var used int64
+				if err := h.DB.Model(&models.ItemAttributeValue{}).
+					Where("attribute_id = ?", current.AttributeID).
+					Count(&used).Error; err != nil {
+					utils.ServerError(c, constant.MsgAttributesUpdateFailed, err)
+					return
+				}
+				if used > 0 {
+					validationErrors[field+".data_type"] = fmt.Sprintf(constant.MsgAttributeTypeInUse, used)
+					continue
+				}
+			} else if input.DataType == constant.AttributeTypeEnum {
+				var removed []struct {
+					Value string
+					Total int64
+				}
+				if err := h.DB.Model(&models.ItemAttributeValue{}).
+					Select("value, COUNT(*) AS total").
+					Where("attribute_id = ? AND value NOT IN ?", current.AttributeID, input.Options).
+					Group("value").
+					Scan(&removed).Error; err != nil {
+					utils.ServerError(c, constant.MsgAttributesUpdateFailed, err)
+					return
+				}
+				if len(removed) > 0 {
+					validationErrors[field+".options"] = fmt.Sprintf(constant.MsgAttributeOptionInUse, removed[0].Value, removed[0].Total)
+					continue
+				}
+			}
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	if len(validationErrors) > 0 {
+		utils.BadRequest(c, constant.MsgAttributesInvalid, validationErrors)
+		return
+	}
+
+	var removedIDs []string
+	for _, attribute := range existing {
+		if _, ok := seen[attribute.Name]; !ok {
+			removedIDs = append(removedIDs, attribute.AttributeID)
+		}
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if len(removedIDs) > 0 {
+			if err := tx.Where("attribute_id IN ?", removedIDs).Delete(&models.ItemAttributeValue{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("attribute_id IN ?", removedIDs).Delete(&models.ItemTypeAttribute{}).Error; err != nil {
+				return err
+			}
+		}
+		for i := range attributes {
+			if err := tx.Save(&attributes[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return services.RecordAudit(tx, userID.(string), constant.AuditActionAttributesUpdate,
+			constant.AuditEntityItemType, itemType.TypeID, gin.H{
+				"attributes": req.Attributes,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constant.MsgAttributesUpdateFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constant.MsgAttributesUpdatedSuccess, toAttributeResponses(attributes))
+}
+
+func toAttributeResponses(attributes []models.ItemTypeAttribute) []dto.ItemTypeAttributeResponse {
+	responses := []dto.ItemTypeAttributeResponse{}
+	for _, attribute := range attributes {
+		responses = append(responses, dto.ItemTypeAttributeResponse{
+			AttributeID: attribute.AttributeID,
+			Name:        attribute.Name,
+			Label:       attribute.Label,
+			DataType:    attribute.DataType,
+			Required:    attribute.Required,
+			Options:     services.AttributeOptions(attribute),
+		})
+	}
+	return responses
+}
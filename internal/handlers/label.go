@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func (h *ItemHandler) PrintLabels(c *gin.Context) {
+	var req dto.ItemLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	// Set default values
+	if req.Symbology == "" {
+		req.Symbology = constants.LabelSymbologyCode128
+	}
+	if req.Format == "" {
+		req.Format = constants.LabelFormatPDF
+	}
+	if req.Copies == 0 {
+		req.Copies = 1
+	}
+
+	var items []models.Item
+	if err := h.DB.Preload("Unit").
+		Preload("Barcodes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("item_id IN ?", req.ItemIDs).
+		Find(&items).Error; err != nil {
+		utils.ServerError(c, constants.MsgFailedFetchItems, err)
+		return
+	}
+
+	itemsByID := make(map[string]models.Item, len(items))
+	for _, item := range items {
+		itemsByID[item.ItemID] = item
+	}
+
+	// Urutan label mengikuti urutan item_ids pada request
+	var labels []utils.Label
+	var missing []string
+	for _, itemID := range req.ItemIDs {
+		item, ok := itemsByID[itemID]
+		if !ok {
+			missing = append(missing, itemID)
+			continue
+		}
+		labels = append(labels, toLabel(item))
+	}
+	if len(missing) > 0 {
+		utils.Error(c, http.StatusNotFound, constants.MsgItemNotFound, gin.H{
+			"item_ids": fmt.Sprintf(constants.MsgLabelItemsNotFound, strings.Join(missing, ", ")),
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	var err error
+	var contentType, extension string
+	if req.Format == constants.LabelFormatZPL {
+		roll := utils.LabelRoll{
+			WidthMM:   req.Layout.LabelWidthMM,
+			HeightMM:  req.Layout.LabelHeightMM,
+			DPI:       req.Layout.DPI,
+			Copies:    req.Copies,
+			Symbology: req.Symbology,
+		}
+		if roll.WidthMM == 0 {
+			roll.WidthMM = 50
+		}
+		if roll.HeightMM == 0 {
+			roll.HeightMM = 25
+		}
+		if roll.DPI == 0 {
+			roll.DPI = 203
+		}
+		err = utils.RenderLabelZPL(&buf, labels, roll)
+		contentType, extension = "text/plain; charset=utf-8", ".zpl"
+	} else {
+		sheet := utils.LabelSheet{
+			PageSize:  req.Layout.PageSize,
+			Columns:   req.Layout.Columns,
+			Rows:      req.Layout.Rows,
+			MarginMM:  req.Layout.MarginMM,
+			GapMM:     req.Layout.GapMM,
+			Skip:      req.Layout.Skip,
+			Symbology: req.Symbology,
+		}
+		if sheet.PageSize == "" {
+			sheet.PageSize = "A4"
+		}
+		if sheet.Columns == 0 {
+			sheet.Columns = 3
+		}
+		if sheet.Rows == 0 {
+			sheet.Rows = 8
+		}
+		if sheet.MarginMM == 0 {
+			sheet.MarginMM = 10
+		}
+		if sheet.GapMM == 0 {
+			sheet.GapMM = 2
+		}
+
+		// Salinan label pada PDF dicetak berurutan
+		var copies []utils.Label
+		for _, label := range labels {
+			for i := 0; i < req.Copies; i++ {
+				copies = append(copies, label)
+			}
+		}
+		err = utils.RenderLabelPDF(&buf, copies, sheet)
+		contentType, extension = "application/pdf", ".pdf"
+	}
+	if errors.Is(err, utils.ErrLabelTooSmall) {
+		utils.BadRequest(c, constants.MsgLabelLayoutTooSmall, gin.H{
+			"layout": constants.MsgLabelLayoutTooSmall,
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgLabelGenerateFailed, err)
+		return
+	}
+
+	filename := constants.MsgLabelFilename + time.Now().Format("20060102_150405") + extension
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// toLabel memilih kode yang dicetak: SKU, barcode pertama, atau ID barang.
+// Semua kode tersebut bisa di-scan lewat endpoint lookup.
+func toLabel(item models.Item) utils.Label {
+	label := utils.Label{
+		Name: item.ItemName,
+		Unit: item.Unit.UnitName,
+		Code: item.ItemID,
+	}
+	if item.SKU != nil {
+		label.SKU = *item.SKU
+		label.Code = *item.SKU
+	} else if len(item.Barcodes) > 0 {
+		label.Code = item.Barcodes[0].Code
+	}
+	return label
+}
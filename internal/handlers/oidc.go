@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"inventory_app_backend/pkg/oidc"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCLogin memulai login SSO. Frontend mengarahkan browser ke
+// authorization_url, lalu mengirim code dan state dari redirect identity
+// provider ke OIDCCallback.
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	provider, ok := h.oidcProvider(c)
+	if !ok {
+		return
+	}
+
+	loginState, err := services.StartOIDCLogin(h.DB)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgOIDCLoginStarted, dto.OIDCLoginResponse{
+		AuthorizationURL: provider.AuthCodeURL(loginState.State, loginState.Nonce, loginState.CodeVerifier),
+		State:            loginState.State,
+	})
+}
+
+// OIDCCallback menukar authorization code dengan token identity provider,
+// membuat atau memperbarui user, lalu mengirim token sesi seperti Login
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	provider, ok := h.oidcProvider(c)
+	if !ok {
+		return
+	}
+
+	loginState, err := services.ConsumeOIDCState(h.DB, req.State)
+	if errors.Is(err, services.ErrOIDCStateInvalid) {
+		utils.BadRequest(c, constants.MsgOIDCStateInvalid, nil)
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	claims, err := provider.Exchange(c.Request.Context(), req.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		// Detail kegagalan dari identity provider hanya dicatat di server
+		log.Printf("Login SSO gagal: %v", err)
+		utils.Error(c, http.StatusUnauthorized, constants.MsgOIDCLoginFailed, nil)
+		return
+	}
+
+	role, err := services.OIDCRoleFor(claims)
+	if errors.Is(err, services.ErrOIDCNoRole) {
+		utils.Forbidden(c, constants.MsgOIDCNoRole)
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	user, err := services.ProvisionOIDCUser(h.DB, claims, role)
+	switch {
+	case errors.Is(err, services.ErrOIDCRoleInvalid):
+		utils.ServerError(c, constants.MsgOIDCRoleInvalid, err)
+		return
+	case errors.Is(err, services.ErrOIDCUsernameTaken):
+		utils.Error(c, http.StatusConflict, constants.MsgOIDCUsernameTaken, nil)
+		return
+	case errors.Is(err, services.ErrOIDCUsernameAbsent):
+		utils.Error(c, http.StatusUnauthorized, constants.MsgOIDCUsernameAbsent, nil)
+		return
+	case err != nil:
+		utils.ServerError(c, constants.MsgUserSaveFail, err)
+		return
+	}
+
+	// User yang dinonaktifkan admin tetap ditolak walaupun aktif di
+	// identity provider
+	if !user.IsActive {
+		utils.Error(c, http.StatusForbidden, constants.MsgUserInactive, constants.MsgForbiddenError)
+		return
+	}
+
+	h.issueLogin(c, user)
+}
+
+// oidcProvider mengambil provider dari konfigurasi. Mengembalikan false jika
+// response error sudah dikirim.
+func (h *AuthHandler) oidcProvider(c *gin.Context) (*oidc.Provider, bool) {
+	provider, err := oidc.Default(c.Request.Context())
+	if errors.Is(err, oidc.ErrDisabled) {
+		utils.NotFound(c, constants.MsgOIDCDisabled)
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("Gagal menghubungi identity provider: %v", err)
+		utils.Error(c, http.StatusBadGateway, constants.MsgOIDCProviderError, nil)
+		return nil, false
+	}
+	return provider, true
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOIDCLoginHidesProviderError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	issuer := httptest.NewServer(http.NotFoundHandler())
+	defer issuer.Close()
+	t.Setenv("OIDC_ISSUER_URL", issuer.URL)
+
+	db, _ := newMockDB(t)
+	h := &AuthHandler{DB: db}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil)
+	h.OIDCLogin(c)
+
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadGateway)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, constants.MsgOIDCProviderError) {
+		t.Errorf("body = %s, want pesan %q", body, constants.MsgOIDCProviderError)
+	}
+	// URL discovery dan status dari identity provider tidak boleh bocor
+	if strings.Contains(body, issuer.URL) || strings.Contains(body, "status 404") {
+		t.Errorf("body = %s, detail error identity provider ikut terkirim", body)
+	}
+}
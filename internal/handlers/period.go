@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PeriodHandler struct {
+	DB *gorm.DB
+}
+
+var errPeriodNotClosed = errors.New("period_not_closed")
+
+func (h *PeriodHandler) ClosePeriod(c *gin.Context) {
+	var req dto.ClosePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	if req.StartDate.After(req.EndDate) {
+		utils.BadRequest(c, constants.MsgInvalidDateRange, nil)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	// Cek tabrakan dengan periode tertutup lain
+	var overlapping models.ClosedPeriod
+	if err := h.DB.Where("status = ? AND start_date <= ? AND end_date >= ?",
+		constants.PeriodStatusClosed,
+		req.EndDate.Format("2006-01-02"),
+		req.StartDate.Format("2006-01-02")).
+		First(&overlapping).Error; err == nil {
+		utils.Error(c, http.StatusConflict, constants.MsgPeriodOverlap, gin.H{
+			"start_date": fmt.Sprintf(constants.MsgPeriodOverlapDetail,
+				overlapping.StartDate.Format("2006-01-02"),
+				overlapping.EndDate.Format("2006-01-02")),
+		})
+		return
+	}
+
+	period := models.ClosedPeriod{
+		PeriodID:  uuid.New().String(),
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Status:    constants.PeriodStatusClosed,
+		Note:      req.Note,
+		ClosedBy:  userID.(string),
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&period).Error; err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionPeriodClose,
+			constants.AuditEntityPeriod, period.PeriodID, gin.H{
+				"start_date": period.StartDate.Format("2006-01-02"),
+				"end_date":   period.EndDate.Format("2006-01-02"),
+				"note":       period.Note,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgPeriodCloseFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusCreated, constants.MsgPeriodClosedSuccess, toPeriodResponse(period))
+}
+
+func (h *PeriodHandler) ReopenPeriod(c *gin.Context) {
+	periodID := c.Param("id")
+
+	var req dto.ReopenPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var period models.ClosedPeriod
+	if err := h.DB.Where("period_id = ?", periodID).First(&period).Error; err != nil {
+		utils.NotFound(c, constants.MsgPeriodNotFound)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		reopenedBy := userID.(string)
+
+		// Hanya periode yang masih tertutup yang bisa dibuka kembali
+		result := tx.Model(&models.ClosedPeriod{}).
+			Where("period_id = ? AND status = ?", period.PeriodID, constants.PeriodStatusClosed).
+			Updates(map[string]interface{}{
+				"status":        constants.PeriodStatusReopened,
+				"reopened_by":   reopenedBy,
+				"reopen_reason": req.Reason,
+				"reopened_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errPeriodNotClosed
+		}
+
+		period.Status = constants.PeriodStatusReopened
+		period.ReopenedBy = &reopenedBy
+		period.ReopenReason = req.Reason
+		period.ReopenedAt = &now
+
+		return services.RecordAudit(tx, reopenedBy, constants.AuditActionPeriodReopen,
+			constants.AuditEntityPeriod, period.PeriodID, gin.H{
+				"start_date": period.StartDate.Format("2006-01-02"),
+				"end_date":   period.EndDate.Format("2006-01-02"),
+				"reason":     req.Reason,
+			})
+	})
+	if errors.Is(err, errPeriodNotClosed) {
+		utils.Error(c, http.StatusConflict, constants.MsgPeriodNotClosed, gin.H{
+			"period_id": constants.MsgPeriodNotClosed,
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgPeriodReopenFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgPeriodReopenedSuccess, toPeriodResponse(period))
+}
+
+func (h *PeriodHandler) GetAllPeriods(c *gin.Context) {
+	var req dto.PeriodListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	query := h.DB.Model(&models.ClosedPeriod{}).Order("start_date DESC")
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	var periods []models.ClosedPeriod
+	if err := query.Find(&periods).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var result []dto.PeriodResponse
+	for _, period := range periods {
+		result = append(result, toPeriodResponse(period))
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgPeriodsFetchSuccess, result)
+}
+
+func toPeriodResponse(period models.ClosedPeriod) dto.PeriodResponse {
+	resp := dto.PeriodResponse{
+		PeriodID:     period.PeriodID,
+		StartDate:    period.StartDate.Format("2006-01-02"),
+		EndDate:      period.EndDate.Format("2006-01-02"),
+		Status:       period.Status,
+		Note:         period.Note,
+		ClosedBy:     period.ClosedBy,
+		ReopenReason: period.ReopenReason,
+		ReopenedAt:   period.ReopenedAt,
+		CreatedAt:    period.CreatedAt,
+	}
+	if period.ReopenedBy != nil {
+		resp.ReopenedBy = *period.ReopenedBy
+	}
+	return resp
+}
+
+// ensureOpenPeriod menolak request jika tanggal berada di periode tertutup.
+// Mengembalikan false jika response error sudah dikirim.
+func ensureOpenPeriod(c *gin.Context, db *gorm.DB, date time.Time) bool {
+	period, err := services.FindClosedPeriod(db, date)
+	if err != nil {
+		utils.ServerError(c, constants.MsgPeriodCheckFailed, err)
+		return false
+	}
+	if period != nil {
+		utils.Error(c, http.StatusConflict, constants.MsgPeriodLocked, gin.H{
+			"error_code": constants.ErrCodePeriodClosed,
+			"date": fmt.Sprintf(constants.MsgPeriodLockedDetail,
+				period.StartDate.Format("2006-01-02"),
+				period.EndDate.Format("2006-01-02")),
+			"period_id": period.PeriodID,
+		})
+		return false
+	}
+	return true
+}
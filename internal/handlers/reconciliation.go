@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReconciliationHandler struct {
+	DB *gorm.DB
+}
+
+func (h *ReconciliationHandler) ReconcileStock(c *gin.Context) {
+	var req dto.ReconcileStockRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	result, err := services.ReconcileStock(h.DB, req.Fix, userID.(string))
+	if err != nil {
+		utils.ServerError(c, constants.MsgReconcileFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgReconcileSuccess, toReconciliationResponse(result))
+}
+
+func toReconciliationResponse(result *services.ReconciliationResult) dto.ReconciliationResponse {
+	resp := dto.ReconciliationResponse{
+		CheckedItems: result.CheckedItems,
+		DriftCount:   len(result.Drifts),
+		Drifts:       []dto.StockDriftResponse{},
+	}
+
+	for _, drift := range result.Drifts {
+		if drift.Fixed {
+			resp.FixedCount++
+		}
+		resp.Drifts = append(resp.Drifts, dto.StockDriftResponse{
+			ItemID:             drift.ItemID,
+			ItemName:           drift.ItemName,
+			RecordedStock:      drift.RecordedStock,
+			ExpectedStock:      drift.ExpectedStock,
+			Difference:         drift.ExpectedStock - drift.RecordedStock,
+			RecordedQuarantine: drift.RecordedQuarantine,
+			ExpectedQuarantine: drift.ExpectedQuarantine,
+			Fixed:              drift.Fixed,
+			Skipped:            drift.Skipped,
+			AdjustmentID:       drift.AdjustmentID,
+		})
+	}
+
+	return resp
+}
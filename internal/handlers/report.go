@@ -6,6 +6,7 @@ import (
 	constants "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 	"strconv"
 	"time"
@@ -21,12 +22,27 @@ type ReportHandler struct {
 
 func (h *ReportHandler) GenerateItemReport(c *gin.Context) {
 	lowStockOnly, _ := strconv.ParseBool(c.DefaultQuery("low_stock_only", "false"))
+	includeArchived, _ := strconv.ParseBool(c.DefaultQuery("include_archived", "false"))
 
-	query := h.DB.Preload("Type").Preload("Unit")
+	query := h.DB.Preload("Type").Preload("Unit").Preload("AttributeValues.Attribute")
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
 	if lowStockOnly {
 		query = query.Where("stock < minimum_stock")
 	}
 
+	// Jenis barang ditampilkan lengkap dengan jalurnya dan filter
+	// per jenis mencakup seluruh sub-jenis
+	tree, err := services.LoadTypeTree(h.DB)
+	if err != nil {
+		utils.ServerError(c, constants.MsgFailedFetchItems, err)
+		return
+	}
+	if typeID := c.Query("type_id"); typeID != "" {
+		query = query.Where("type_id IN ?", tree.Descendants(typeID))
+	}
+
 	query = query.Debug()
 	var items []models.Item
 	if err := query.Find(&items).Error; err != nil {
@@ -44,12 +60,14 @@ func (h *ReportHandler) GenerateItemReport(c *gin.Context) {
 		}
 
 		reportData = append(reportData, dto.ItemReportDTO{
-			ItemName:     item.ItemName,
-			TypeName:     item.Type.TypeName,
-			UnitName:     item.Unit.UnitName,
-			Stock:        item.Stock,
-			MinimumStock: item.MinimumStock,
-			Status:       status,
+			ItemName:        item.ItemName,
+			TypeName:        tree.PathName(item.TypeID),
+			UnitName:        item.Unit.UnitName,
+			Stock:           item.Stock,
+			MinimumStock:    item.MinimumStock,
+			QuarantineStock: item.QuarantineStock,
+			Status:          status,
+			Attributes:      formatAttributes(item),
 		})
 	}
 
@@ -74,7 +92,9 @@ func (h *ReportHandler) GenerateItemReport(c *gin.Context) {
 		constants.MsgReportHeaderUnit,
 		constants.MsgReportHeaderCurrentStock,
 		constants.MsgReportHeaderMinStock,
+		constants.MsgReportHeaderQuarantine,
 		constants.MsgReportHeaderStatus,
+		constants.MsgReportHeaderAttributes,
 	}
 
 	for i, header := range headers {
@@ -91,7 +111,9 @@ func (h *ReportHandler) GenerateItemReport(c *gin.Context) {
 			data.UnitName,
 			data.Stock,
 			data.MinimumStock,
+			data.QuarantineStock,
 			data.Status,
+			data.Attributes,
 		}
 
 		for colIdx, value := range values {
@@ -170,6 +192,16 @@ func (h *ReportHandler) GenerateTransactionReport(c *gin.Context) {
 		query = query.Where("transaction_type = ?", txType)
 	}
 
+	tree, err := services.LoadTypeTree(h.DB)
+	if err != nil {
+		utils.ServerError(c, constants.MsgFailedFetchTransactions, err)
+		return
+	}
+	if typeID := c.Query("type_id"); typeID != "" {
+		query = query.Where("item_id IN (?)",
+			h.DB.Model(&models.Item{}).Select("item_id").Where("type_id IN ?", tree.Descendants(typeID)))
+	}
+
 	var transactions []models.Transaction
 	if err := query.Find(&transactions).Error; err != nil {
 		utils.ServerError(c, constants.MsgFailedFetchTransactions, err)
@@ -181,7 +213,7 @@ func (h *ReportHandler) GenerateTransactionReport(c *gin.Context) {
 	for _, tx := range transactions {
 		reportData = append(reportData, dto.TransactionReportDTO{
 			ItemName:    tx.Item.ItemName,
-			TypeName:    tx.Item.Type.TypeName,
+			TypeName:    tree.PathName(tx.Item.TypeID),
 			Quantity:    tx.Quantity,
 			Date:        tx.Date,
 			Description: tx.Description,
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type RoleHandler struct {
+	DB *gorm.DB
+}
+
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	var permissions []models.Permission
+	if err := h.DB.Order("permission ASC").Find(&permissions).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	responses := []dto.PermissionResponse{}
+	for _, permission := range permissions {
+		responses = append(responses, dto.PermissionResponse{
+			Permission:  permission.Permission,
+			Description: permission.Description,
+		})
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgPermissionsFetchSuccess, responses)
+}
+
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	var roles []models.Role
+	if err := h.DB.Preload("Permissions").Order("name ASC").Find(&roles).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var counts []struct {
+		Role  string
+		Total int64
+	}
+	if err := h.DB.Model(&models.User{}).
+		Select("role, COUNT(*) AS total").
+		Group("role").
+		Scan(&counts).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	userCounts := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		userCounts[count.Role] = count.Total
+	}
+
+	responses := []dto.RoleResponse{}
+	for _, role := range roles {
+		responses = append(responses, toRoleResponse(role, userCounts[role.Name]))
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgRolesFetchSuccess, responses)
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	if !roleNamePattern.MatchString(req.Name) {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"name": constants.MsgRoleNameInvalid,
+		})
+		return
+	}
+
+	var existing models.Role
+	if err := h.DB.First(&existing, "name = ?", req.Name).Error; err == nil {
+		utils.Error(c, http.StatusConflict, constants.MsgRoleExists, gin.H{
+			"name": constants.MsgRoleExists,
+		})
+		return
+	}
+
+	names, ok := resolvePermissions(c, h.DB, req.Permissions)
+	if !ok {
+		return
+	}
+
+	role := models.Role{
+		Name:             req.Name,
+		Description:      req.Description,
+		RequireTwoFactor: req.RequireTwoFactor,
+	}
+	for _, name := range names {
+		role.Permissions = append(role.Permissions, models.RolePermission{RoleName: role.Name, Permission: name})
+	}
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionRoleCreate,
+			constants.AuditEntityRole, role.Name, gin.H{
+				"permissions":        names,
+				"require_two_factor": role.RequireTwoFactor,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgRoleSaveFailed, err)
+		return
+	}
+	services.InvalidatePermissionCache()
+
+	utils.Success(c, http.StatusCreated, constants.MsgRoleCreatedSuccess, toRoleResponse(role, 0))
+}
+
+// UpdateRole mengubah deskripsi dan/atau mengganti seluruh izin role. Izin
+// role admin dikunci agar tidak ada yang kehilangan akses ke pengaturan role.
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var role models.Role
+	if err := h.DB.Preload("Permissions").First(&role, "name = ?", c.Param("name")).Error; err != nil {
+		utils.NotFound(c, constants.MsgRoleNotFound)
+		return
+	}
+
+	var permissions []models.RolePermission
+	if req.Permissions != nil {
+		if role.Name == constants.RoleAdmin {
+			utils.Error(c, http.StatusConflict, constants.MsgRoleAdminLocked, nil)
+			return
+		}
+		names, ok := resolvePermissions(c, h.DB, *req.Permissions)
+		if !ok {
+			return
+		}
+		for _, name := range names {
+			permissions = append(permissions, models.RolePermission{RoleName: role.Name, Permission: name})
+		}
+	}
+
+	before := permissionNames(role.Permissions)
+	beforeTwoFactor := role.RequireTwoFactor
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if req.Description != nil {
+			role.Description = *req.Description
+			if err := tx.Model(&role).Update("description", role.Description).Error; err != nil {
+				return err
+			}
+		}
+
+		if req.RequireTwoFactor != nil {
+			role.RequireTwoFactor = *req.RequireTwoFactor
+			if err := tx.Model(&role).Update("require_two_factor", role.RequireTwoFactor).Error; err != nil {
+				return err
+			}
+		}
+
+		if req.Permissions != nil {
+			if err := tx.Where("role_name = ?", role.Name).Delete(&models.RolePermission{}).Error; err != nil {
+				return err
+			}
+			if len(permissions) > 0 {
+				if err := tx.Create(&permissions).Error; err != nil {
+					return err
+				}
+			}
+			role.Permissions = permissions
+		}
+
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionRoleUpdate,
+			constants.AuditEntityRole, role.Name, gin.H{
+				"before":                    before,
+				"after":                     permissionNames(role.Permissions),
+				"require_two_factor_before": beforeTwoFactor,
+				"require_two_factor_after":  role.RequireTwoFactor,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgRoleSaveFailed, err)
+		return
+	}
+	services.InvalidatePermissionCache()
+
+	var userCount int64
+	h.DB.Model(&models.User{}).Where("role = ?", role.Name).Count(&userCount)
+
+	utils.Success(c, http.StatusOK, constants.MsgRoleUpdatedSuccess, toRoleResponse(role, userCount))
+}
+
+var errRoleInUse = errors.New("role_in_use")
+
+// DeleteRole menghapus role buatan admin yang sudah tidak dipakai user
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var role models.Role
+	if err := h.DB.Preload("Permissions").First(&role, "name = ?", c.Param("name")).Error; err != nil {
+		utils.NotFound(c, constants.MsgRoleNotFound)
+		return
+	}
+
+	if role.IsSystem {
+		utils.Error(c, http.StatusConflict, constants.MsgRoleSystemDelete, nil)
+		return
+	}
+
+	var userCount int64
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Kunci role agar tidak ada user yang diberi role ini di tengah jalan
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&models.Role{}, "name = ?", role.Name).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.User{}).Where("role = ?", role.Name).Count(&userCount).Error; err != nil {
+			return err
+		}
+		if userCount > 0 {
+			return errRoleInUse
+		}
+
+		if err := tx.Delete(&role).Error; err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, userID.(string), constants.AuditActionRoleDelete,
+			constants.AuditEntityRole, role.Name, gin.H{
+				"permissions": permissionNames(role.Permissions),
+			})
+	})
+	if errors.Is(err, errRoleInUse) {
+		utils.Error(c, http.StatusConflict, fmt.Sprintf(constants.MsgRoleInUse, userCount), nil)
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgRoleSaveFailed, err)
+		return
+	}
+	services.InvalidatePermissionCache()
+
+	utils.Success(c, http.StatusOK, constants.MsgRoleDeletedSuccess, nil)
+}
+
+// resolvePermissions memastikan semua izin terdaftar di tabel permissions dan
+// bukan izin khusus admin. Izin duplikat digabung. Mengembalikan false jika response error sudah
+// dikirim.
+func resolvePermissions(c *gin.Context, db *gorm.DB, names []string) ([]string, bool) {
+	var known []string
+	if err := db.Model(&models.Permission{}).Pluck("permission", &known).Error; err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return nil, false
+	}
+	registered := make(map[string]bool, len(known))
+	for _, name := range known {
+		registered[name] = true
+	}
+
+	validationErrors := gin.H{}
+	seen := make(map[string]bool, len(names))
+	var permissions []string
+	for i, name := range names {
+		if !registered[name] {
+			validationErrors[fmt.Sprintf("permissions.%d", i)] = fmt.Sprintf(constants.MsgRolePermissionUnknown, name)
+			continue
+		}
+		if constants.AdminOnlyPermissions[name] {
+			validationErrors[fmt.Sprintf("permissions.%d", i)] = fmt.Sprintf(constants.MsgRolePermissionAdminOnly, name)
+			continue
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		permissions = append(permissions, name)
+	}
+	if len(validationErrors) > 0 {
+		utils.BadRequest(c, constants.MsgValidationFailed, validationErrors)
+		return nil, false
+	}
+	return permissions, true
+}
+
+// ensureRoleExists mengecek role terdaftar di tabel roles. Mengembalikan
+// false jika response error sudah dikirim.
+func ensureRoleExists(c *gin.Context, db *gorm.DB, name string) bool {
+	// Service account hanya dibuat lewat API key dan tidak bisa login
+	if name == constants.RoleServiceAccount {
+		utils.BadRequest(c, constants.MsgInvalidRole, gin.H{
+			"role": constants.MsgRoleServiceAccountOnly,
+		})
+		return false
+	}
+
+	var role models.Role
+	if err := db.First(&role, "name = ?", name).Error; err != nil {
+		utils.BadRequest(c, constants.MsgInvalidRole, gin.H{
+			"role": constants.MsgInvalidRoleValue,
+		})
+		return false
+	}
+	return true
+}
+
+func permissionNames(permissions []models.RolePermission) []string {
+	names := []string{}
+	for _, permission := range permissions {
+		names = append(names, permission.Permission)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func toRoleResponse(role models.Role, userCount int64) dto.RoleResponse {
+	return dto.RoleResponse{
+		Name:             role.Name,
+		Description:      role.Description,
+		IsSystem:         role.IsSystem,
+		RequireTwoFactor: role.RequireTwoFactor,
+		Permissions:      permissionNames(role.Permissions),
+		UserCount:        userCount,
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	constant "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/utils"
+	"mime/multipart"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// readImportFile membaca file impor dan memastikan kolom wajib tersedia.
+// Mengembalikan false jika response error sudah dikirim.
+func readImportFile(c *gin.Context, file *multipart.FileHeader, requiredColumns []string) ([]string, [][]string, bool) {
+	header, rows, err := utils.ReadSpreadsheet(file)
+	switch {
+	case errors.Is(err, utils.ErrSpreadsheetFormat):
+		utils.BadRequest(c, constant.MsgImportInvalidFile, gin.H{
+			"file": constant.MsgImportAllowedFormat,
+		})
+		return nil, nil, false
+	case errors.Is(err, utils.ErrSpreadsheetTooLarge):
+		utils.BadRequest(c, constant.MsgImportInvalidFile, gin.H{
+			"file": constant.MsgImportAllowedSize,
+		})
+		return nil, nil, false
+	case errors.Is(err, utils.ErrSpreadsheetEmpty):
+		utils.BadRequest(c, constant.MsgImportEmpty, gin.H{
+			"file": constant.MsgImportEmpty,
+		})
+		return nil, nil, false
+	case err != nil:
+		utils.ServerError(c, constant.MsgImportInvalidFile, err)
+		return nil, nil, false
+	}
+
+	for _, column := range requiredColumns {
+		found := false
+		for _, name := range header {
+			if name == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			utils.BadRequest(c, constant.MsgImportInvalidFile, gin.H{
+				"file": fmt.Sprintf(constant.MsgImportMissingColumn, column),
+			})
+			return nil, nil, false
+		}
+	}
+
+	if len(rows) > maxImportRows {
+		utils.BadRequest(c, constant.MsgImportInvalidFile, gin.H{
+			"file": fmt.Sprintf(constant.MsgImportTooManyRows, maxImportRows),
+		})
+		return nil, nil, false
+	}
+
+	return header, rows, true
+}
+
+// writeSpreadsheet mengirim data sebagai file xlsx (default) atau csv
+func writeSpreadsheet(c *gin.Context, format, filename string, header []string, rows [][]string) {
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", "attachment; filename="+filename+".csv")
+
+		w := csv.NewWriter(c.Writer)
+		w.Write(header)
+		w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			utils.ServerError(c, constant.MsgFailedGenerateExcel, err)
+			return
+		}
+		c.Abort()
+		return
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Sheet1"
+	for i, value := range header {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, value)
+	}
+	for rowIdx, row := range rows {
+		for colIdx, value := range row {
+			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+2)
+			f.SetCellStr(sheetName, cell, value)
+		}
+	}
+	for i := range header {
+		col, _ := excelize.ColumnNumberToName(i + 1)
+		f.SetColWidth(sheetName, col, col, 20)
+	}
+
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Disposition", "attachment; filename="+filename+".xlsx")
+	c.Header("Content-Transfer-Encoding", "binary")
+
+	if err := f.Write(c.Writer); err != nil {
+		utils.ServerError(c, constant.MsgFailedGenerateExcel, err)
+		return
+	}
+
+	c.Abort()
+}
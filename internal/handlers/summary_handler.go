@@ -4,6 +4,7 @@ import (
 	constants "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 	"net/http"
 
@@ -18,24 +19,53 @@ type SummaryHandler struct {
 func (h *SummaryHandler) GetInventorySummary(c *gin.Context) {
 	summaryType := c.Query("type")
 
-	var totalStock, totalIn, totalOut int64
+	var totalStock, totalIn, totalOut, totalRejected, totalQuarantine int64
 
-	// Hitung total data barang dari semua item
-	if err := h.DB.Model(&models.Item{}).Select("COUNT(*)").Scan(&totalStock).Error; err != nil {
+	// Rekap per jenis barang mencakup seluruh sub-jenisnya
+	scope := func(query *gorm.DB) *gorm.DB { return query }
+	if typeID := c.Query("type_id"); typeID != "" {
+		typeIDs, err := services.TypeWithDescendants(h.DB, typeID)
+		if err != nil {
+			utils.ServerError(c, constants.MsgSummaryTotalFailed, err)
+			return
+		}
+		scope = func(query *gorm.DB) *gorm.DB {
+			return query.Where("item_id IN (?)",
+				h.DB.Model(&models.Item{}).Select("item_id").Where("type_id IN ?", typeIDs))
+		}
+	}
+
+	// Hitung total data barang yang masih aktif
+	if err := scope(h.DB.Model(&models.Item{})).Where("archived_at IS NULL").Select("COUNT(*)").Scan(&totalStock).Error; err != nil {
 		utils.ServerError(c, constants.MsgSummaryTotalFailed, err)
 		return
 	}
 
-	// Hitung total barang masuk
-	if err := h.DB.Model(&models.Transaction{}).
-		Where("transaction_type = ?", constants.TransactionTypeIn).
-		Select("SUM(quantity)").Scan(&totalIn).Error; err != nil {
+	// Hitung total barang masuk yang sudah bisa dipakai (tidak termasuk karantina)
+	if err := scope(h.DB.Model(&models.Transaction{})).
+		Where("transaction_type = ? AND inspection_status <> ?", constants.TransactionTypeIn, constants.InspectionStatusPending).
+		Select("COALESCE(SUM(quantity), 0)").Scan(&totalIn).Error; err != nil {
 		utils.ServerError(c, constants.MsgSummaryInFailed, err)
 		return
 	}
 
+	// Kurangi barang masuk yang ditolak saat inspeksi
+	if err := scope(h.DB.Model(&models.Inspection{})).
+		Select("COALESCE(SUM(rejected_quantity), 0)").Scan(&totalRejected).Error; err != nil {
+		utils.ServerError(c, constants.MsgSummaryInFailed, err)
+		return
+	}
+	totalIn -= totalRejected
+
+	// Hitung stok yang masih di karantina
+	if err := scope(h.DB.Model(&models.Item{})).
+		Select("COALESCE(SUM(quarantine_stock), 0)").Scan(&totalQuarantine).Error; err != nil {
+		utils.ServerError(c, constants.MsgSummaryQuarantineFailed, err)
+		return
+	}
+
 	// Hitung total barang keluar
-	if err := h.DB.Model(&models.Transaction{}).
+	if err := scope(h.DB.Model(&models.Transaction{})).
 		Where("transaction_type = ?", constants.TransactionTypeOut).
 		Select("SUM(quantity)").Scan(&totalOut).Error; err != nil {
 		utils.ServerError(c, constants.MsgSummaryOutFailed, err)
@@ -51,9 +81,10 @@ func (h *SummaryHandler) GetInventorySummary(c *gin.Context) {
 		resp = dto.SummaryResponse{ItemsOut: totalOut}
 	default:
 		resp = dto.SummaryResponse{
-			TotalItems: totalStock,
-			ItemsIn:    totalIn,
-			ItemsOut:   totalOut,
+			TotalItems:       totalStock,
+			ItemsIn:          totalIn,
+			ItemsOut:         totalOut,
+			ItemsQuarantined: totalQuarantine,
 		}
 	}
 
@@ -1,22 +1,33 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	constant "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TransactionHandler struct {
 	DB *gorm.DB
 }
 
+var (
+	errTransactionInspected = errors.New("transaction_inspected")
+	// errTransactionChanged dikembalikan jika transaksi diubah atau dihapus
+	// proses lain setelah dibaca
+	errTransactionChanged = errors.New("transaction_changed")
+)
+
 func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	var req dto.CreateTransactionRequest
 	fmt.Printf("%+v\n", req)
@@ -27,6 +38,25 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
+	// Cek periode akuntansi masih terbuka
+	if !ensureOpenPeriod(c, h.DB, req.Date) {
+		return
+	}
+
+	// Barcode hasil scan bisa dipakai sebagai pengganti item_id
+	if req.ItemID == "" {
+		found, err := services.FindItemByCode(h.DB, req.Barcode)
+		if err != nil {
+			utils.ServerError(c, constant.MsgInternalServerError, err)
+			return
+		}
+		if found == nil {
+			utils.NotFound(c, constant.MsgItemLookupNotFound)
+			return
+		}
+		req.ItemID = found.ItemID
+	}
+
 	// Cek item exists
 	var item models.Item
 	if err := h.DB.First(&item, "item_id = ?", req.ItemID).Error; err != nil {
@@ -34,12 +64,8 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	// Untuk transaksi keluar, cek stok cukup
-	if req.TransactionType == constant.TransactionTypeOut && item.Stock < req.Quantity {
-		utils.Error(c, http.StatusBadRequest, constant.MsgInsufficientStock, gin.H{
-			"current_stock": item.Stock,
-			"required":      req.Quantity,
-		})
+	// Barang yang diarsipkan tidak boleh mendapat pergerakan baru
+	if !ensureItemActive(c, item) {
 		return
 	}
 
@@ -50,18 +76,57 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
+	// Barang masuk untuk item yang wajib inspeksi masuk ke karantina dulu
+	inspectionStatus := constant.InspectionStatusNone
+	if req.TransactionType == constant.TransactionTypeIn && item.RequiresInspection {
+		inspectionStatus = constant.InspectionStatusPending
+	}
+
 	// Buat transaksi
 	newTransaction := models.Transaction{
-		TransactionID:   uuid.New().String(),
-		ItemID:          req.ItemID,
-		Date:            req.Date,
-		Quantity:        req.Quantity,
-		TransactionType: req.TransactionType,
-		Description:     req.Description,
-		UserID:          userID.(string),
+		TransactionID:    uuid.New().String(),
+		ItemID:           req.ItemID,
+		Date:             req.Date,
+		Quantity:         req.Quantity,
+		TransactionType:  req.TransactionType,
+		InspectionStatus: inspectionStatus,
+		Description:      req.Description,
+		UserID:           userID.(string),
 	}
 
-	if err := h.DB.Create(&newTransaction).Error; err != nil {
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Barang dikunci sebelum cek stok agar dua transaksi keluar yang
+		// bersamaan tidak sama-sama lolos cek saldo
+		locked, err := lockItems(tx, item.ItemID)
+		if err != nil {
+			return err
+		}
+
+		// Untuk transaksi keluar, cek stok cukup lalu saldo historis di
+		// tanggal transaksi dan setelahnya
+		if req.TransactionType == constant.TransactionTypeOut {
+			current := locked[item.ItemID]
+			if current.Stock < req.Quantity {
+				return &stockBalanceError{
+					message: constant.MsgInsufficientStock,
+					details: gin.H{
+						"current_stock": current.Stock,
+						"required":      req.Quantity,
+					},
+				}
+			}
+			movement := services.StockMovement{Date: req.Date, Delta: -req.Quantity}
+			if err := checkStockBalance(tx, current, nil, []services.StockMovement{movement}); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&newTransaction).Error
+	})
+	if respondStockError(c, err) {
+		return
+	}
+	if err != nil {
 		utils.ServerError(c, constant.MsgTransactionCreatedFailed, err)
 		return
 	}
@@ -71,20 +136,276 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	h.DB.First(&updatedItem, "item_id = ?", req.ItemID)
 
 	resp := dto.TransactionResponse{
-		TransactionID:   newTransaction.TransactionID,
-		ItemID:          newTransaction.ItemID,
-		ItemName:        item.ItemName,
-		Date:            newTransaction.Date,
-		Quantity:        newTransaction.Quantity,
-		TransactionType: newTransaction.TransactionType,
-		Description:     newTransaction.Description,
-		CurrentStock:    updatedItem.Stock,
-		CreatedAt:       newTransaction.CreatedAt,
+		TransactionID:    newTransaction.TransactionID,
+		ItemID:           newTransaction.ItemID,
+		ItemName:         item.ItemName,
+		Date:             newTransaction.Date,
+		Quantity:         newTransaction.Quantity,
+		TransactionType:  newTransaction.TransactionType,
+		InspectionStatus: newTransaction.InspectionStatus,
+		Description:      newTransaction.Description,
+		CurrentStock:     updatedItem.Stock,
+		CreatedAt:        newTransaction.CreatedAt,
 	}
 
 	utils.Success(c, http.StatusCreated, constant.MsgTransactionCreatedSuccess, resp)
 }
 
+func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
+	transactionID := c.Param("id")
+
+	var req dto.UpdateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
+	// Cek transaksi exists
+	var transaction models.Transaction
+	if err := h.DB.Preload("Item").First(&transaction, "transaction_id = ?", transactionID).Error; err != nil {
+		utils.NotFound(c, constant.MsgTransactionNotFound)
+		return
+	}
+
+	if !ensureNotKitTransaction(c, transaction) {
+		return
+	}
+	if !ensureNotReconciliationAdjustment(c, transaction) {
+		return
+	}
+
+	// Tanggal lama dan tanggal baru harus berada di periode terbuka
+	if !ensureOpenPeriod(c, h.DB, transaction.Date) {
+		return
+	}
+	if req.Date != nil && !ensureOpenPeriod(c, h.DB, *req.Date) {
+		return
+	}
+
+	oldItem := transaction.Item
+	updated := transaction
+
+	if req.Date != nil {
+		updated.Date = *req.Date
+	}
+	if req.Quantity != nil {
+		updated.Quantity = *req.Quantity
+	}
+	if req.Description != nil {
+		updated.Description = *req.Description
+	}
+
+	newItem := oldItem
+	if req.ItemID != nil && *req.ItemID != transaction.ItemID {
+		if err := h.DB.First(&newItem, "item_id = ?", *req.ItemID).Error; err != nil {
+			utils.NotFound(c, constant.MsgItemNotFound)
+			return
+		}
+		if !ensureItemActive(c, newItem) {
+			return
+		}
+		updated.ItemID = newItem.ItemID
+	}
+
+	// Cek stok dan simpan perubahan dalam satu transaksi database, trigger
+	// akan menghitung ulang stok barang lama dan baru
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Baris transaksi dikunci lebih dulu dengan urutan yang sama seperti
+		// inspeksi agar tidak deadlock, lalu aturan inspeksi dievaluasi pada
+		// data terbaru karena inspeksi bisa selesai setelah data dibaca di atas
+		var current models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "transaction_id = ?", transaction.TransactionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errTransactionChanged
+			}
+			return err
+		}
+		if current.ItemID != transaction.ItemID || !current.Date.Equal(transaction.Date) ||
+			current.Quantity != transaction.Quantity || current.Description != transaction.Description {
+			return errTransactionChanged
+		}
+
+		locked, err := lockItems(tx, oldItem.ItemID, newItem.ItemID)
+		if err != nil {
+			return err
+		}
+		lockedOld, lockedNew := locked[oldItem.ItemID], locked[newItem.ItemID]
+
+		// Jumlah dan barang transaksi yang sudah diinspeksi terkunci
+		if current.InspectionStatus == constant.InspectionStatusInspected &&
+			(updated.Quantity != current.Quantity || updated.ItemID != current.ItemID) {
+			return errTransactionInspected
+		}
+
+		// Status karantina mengikuti barang yang baru
+		updated.InspectionStatus = current.InspectionStatus
+		if updated.TransactionType == constant.TransactionTypeIn &&
+			current.InspectionStatus != constant.InspectionStatusInspected {
+			updated.InspectionStatus = constant.InspectionStatusNone
+			if lockedNew.RequiresInspection {
+				updated.InspectionStatus = constant.InspectionStatusPending
+			}
+		}
+
+		released, err := services.ReleasedQuantity(tx, transaction.TransactionID)
+		if err != nil {
+			return err
+		}
+		oldDelta := services.MovementDelta(current.TransactionType, current.InspectionStatus, current.Quantity, released)
+		newDelta := services.MovementDelta(updated.TransactionType, updated.InspectionStatus, updated.Quantity, released)
+		newMovement := []services.StockMovement{{Date: updated.Date, Delta: newDelta}}
+
+		if updated.ItemID == transaction.ItemID {
+			// Cek stok saat ini lalu saldo historis untuk barang yang sama
+			if projected := lockedOld.Stock - oldDelta + newDelta; projected < 0 && newDelta < oldDelta {
+				return &stockBalanceError{
+					message: constant.MsgInsufficientStock,
+					details: gin.H{
+						"current_stock": lockedOld.Stock,
+						"required":      oldDelta - newDelta,
+					},
+				}
+			}
+			if err := checkStockBalance(tx, lockedOld, []string{transaction.TransactionID}, newMovement); err != nil {
+				return err
+			}
+		} else {
+			// Barang lama kehilangan transaksi ini, barang baru mendapatkannya
+			if projected := lockedOld.Stock - oldDelta; projected < 0 {
+				return &stockBalanceError{
+					message: constant.MsgInsufficientStock,
+					details: gin.H{
+						"current_stock": lockedOld.Stock,
+						"required":      oldDelta,
+					},
+				}
+			}
+			if projected := lockedNew.Stock + newDelta; projected < 0 {
+				return &stockBalanceError{
+					message: constant.MsgInsufficientStock,
+					details: gin.H{
+						"current_stock": lockedNew.Stock,
+						"required":      -newDelta,
+					},
+				}
+			}
+			if err := checkStockBalance(tx, lockedOld, []string{transaction.TransactionID}, nil); err != nil {
+				return err
+			}
+			if err := checkStockBalance(tx, lockedNew, nil, newMovement); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.Transaction{}).
+			Where("transaction_id = ?", transaction.TransactionID).
+			Updates(map[string]interface{}{
+				"item_id":           updated.ItemID,
+				"date":              updated.Date,
+				"quantity":          updated.Quantity,
+				"inspection_status": updated.InspectionStatus,
+				"description":       updated.Description,
+			}).Error; err != nil {
+			return err
+		}
+
+		beforeJSON, _ := json.Marshal(toTransactionSnapshot(current))
+		afterJSON, _ := json.Marshal(toTransactionSnapshot(updated))
+		return tx.Create(&models.TransactionRevision{
+			RevisionID:    uuid.New().String(),
+			TransactionID: transaction.TransactionID,
+			OldValues:     string(beforeJSON),
+			NewValues:     string(afterJSON),
+			Reason:        req.Reason,
+			UserID:        userID.(string),
+		}).Error
+	})
+	if respondStockError(c, err) {
+		return
+	}
+	if errors.Is(err, errTransactionInspected) {
+		utils.Error(c, http.StatusConflict, constant.MsgTransactionInspected, gin.H{
+			"transaction_id": constant.MsgTransactionInspected,
+		})
+		return
+	}
+	if errors.Is(err, errTransactionChanged) {
+		utils.Error(c, http.StatusConflict, constant.MsgTransactionChanged, gin.H{
+			"transaction_id": constant.MsgTransactionChanged,
+		})
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constant.MsgTransactionUpdateFailed, err)
+		return
+	}
+
+	var result models.Transaction
+	if err := h.DB.Preload("Item").First(&result, "transaction_id = ?", transaction.TransactionID).Error; err != nil {
+		utils.ServerError(c, constant.MsgTransactionUpdateFailed, err)
+		return
+	}
+
+	resp := dto.TransactionResponse{
+		TransactionID:    result.TransactionID,
+		ItemID:           result.ItemID,
+		ItemName:         result.Item.ItemName,
+		Image:            itemThumbnail(result.Item),
+		Date:             result.Date,
+		Quantity:         result.Quantity,
+		TransactionType:  result.TransactionType,
+		InspectionStatus: result.InspectionStatus,
+		Description:      result.Description,
+		CurrentStock:     result.Item.Stock,
+		CreatedAt:        result.CreatedAt,
+	}
+
+	utils.Success(c, http.StatusOK, constant.MsgTransactionUpdatedSuccess, resp)
+}
+
+func (h *TransactionHandler) GetTransactionRevisions(c *gin.Context) {
+	transactionID := c.Param("id")
+
+	var transaction models.Transaction
+	if err := h.DB.First(&transaction, "transaction_id = ?", transactionID).Error; err != nil {
+		utils.NotFound(c, constant.MsgTransactionNotFound)
+		return
+	}
+
+	var revisions []models.TransactionRevision
+	if err := h.DB.Preload("User").
+		Where("transaction_id = ?", transactionID).
+		Order("created_at DESC").
+		Find(&revisions).Error; err != nil {
+		utils.ServerError(c, constant.MsgInternalServerError, err)
+		return
+	}
+
+	var result []dto.TransactionRevisionResponse
+	for _, revision := range revisions {
+		resp := dto.TransactionRevisionResponse{
+			RevisionID:    revision.RevisionID,
+			TransactionID: revision.TransactionID,
+			Reason:        revision.Reason,
+			UserID:        revision.UserID,
+			Username:      revision.User.Username,
+			CreatedAt:     revision.CreatedAt,
+		}
+		json.Unmarshal([]byte(revision.OldValues), &resp.Before)
+		json.Unmarshal([]byte(revision.NewValues), &resp.After)
+		result = append(result, resp)
+	}
+
+	utils.Success(c, http.StatusOK, constant.MsgRevisionsFetchSuccess, result)
+}
+
 func (h *TransactionHandler) GetAllTransactions(c *gin.Context) {
 	var req dto.TransactionListRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
@@ -137,6 +458,10 @@ func (h *TransactionHandler) GetAllTransactions(c *gin.Context) {
 		query = query.Where("transaction_type = ?", req.TypeFilter)
 	}
 
+	if req.ReferenceID != "" {
+		query = query.Where("reference_id = ?", req.ReferenceID)
+	}
+
 	// Get total data
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -154,18 +479,7 @@ func (h *TransactionHandler) GetAllTransactions(c *gin.Context) {
 	// Map to response
 	var transactionResponses []dto.TransactionResponse
 	for _, t := range transactions {
-		transactionResponses = append(transactionResponses, dto.TransactionResponse{
-			TransactionID:   t.TransactionID,
-			ItemID:          t.ItemID,
-			ItemName:        t.Item.ItemName,
-			Image:           t.Item.Image,
-			Date:            t.Date,
-			Quantity:        t.Quantity,
-			TransactionType: t.TransactionType,
-			Description:     t.Description,
-			CurrentStock:    t.Item.Stock,
-			CreatedAt:       t.CreatedAt,
-		})
+		transactionResponses = append(transactionResponses, toTransactionResponse(t))
 	}
 
 	// Calculate pagination
@@ -197,11 +511,43 @@ func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
 		return
 	}
 
+	if !ensureNotKitTransaction(c, transaction) {
+		return
+	}
+	if !ensureNotReconciliationAdjustment(c, transaction) {
+		return
+	}
+
+	// Transaksi di periode tertutup tidak boleh dihapus
+	if !ensureOpenPeriod(c, h.DB, transaction.Date) {
+		return
+	}
+
 	// Simpan stok sebelum dihapus
 	originalStock := transaction.Item.Stock
 
-	// Hapus transaksi
-	if err := h.DB.Delete(&transaction).Error; err != nil {
+	// Hapus transaksi, lampirannya dihapus dari storage sesuai retensi
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Menghapus transaksi masuk tidak boleh membuat saldo historis negatif
+		if transaction.TransactionType == constant.TransactionTypeIn {
+			locked, err := lockItems(tx, transaction.ItemID)
+			if err != nil {
+				return err
+			}
+			if err := checkStockBalance(tx, locked[transaction.ItemID], []string{transaction.TransactionID}, nil); err != nil {
+				return err
+			}
+		}
+
+		if err := voidTransactionAttachments(tx, transaction); err != nil {
+			return err
+		}
+		return tx.Delete(&transaction).Error
+	})
+	if respondStockError(c, err) {
+		return
+	}
+	if err != nil {
 		utils.ServerError(c, constant.MsgTransactionDeleteFailed, err)
 		return
 	}
@@ -226,3 +572,129 @@ func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
 
 	utils.Success(c, http.StatusOK, constant.MsgTransactionDeletedSuccess, response)
 }
+
+func toTransactionResponse(t models.Transaction) dto.TransactionResponse {
+	resp := dto.TransactionResponse{
+		TransactionID:    t.TransactionID,
+		ItemID:           t.ItemID,
+		ItemName:         t.Item.ItemName,
+		Image:            itemThumbnail(t.Item),
+		Date:             t.Date,
+		Quantity:         t.Quantity,
+		TransactionType:  t.TransactionType,
+		InspectionStatus: t.InspectionStatus,
+		Description:      t.Description,
+		CurrentStock:     t.Item.Stock,
+		CreatedAt:        t.CreatedAt,
+	}
+	if t.ReferenceType != nil {
+		resp.ReferenceType = *t.ReferenceType
+	}
+	if t.ReferenceID != nil {
+		resp.ReferenceID = *t.ReferenceID
+	}
+	return resp
+}
+
+// ensureNotKitTransaction menolak perubahan satu sisi dari transaksi perakitan
+// kit agar stok komponen dan kit tetap seimbang.
+// Mengembalikan false jika response error sudah dikirim.
+func ensureNotKitTransaction(c *gin.Context, t models.Transaction) bool {
+	if t.ReferenceType != nil &&
+		(*t.ReferenceType == constant.ReferenceTypeKitAssembly || *t.ReferenceType == constant.ReferenceTypeKitDisassembly) {
+		utils.Error(c, http.StatusConflict, constant.MsgTransactionLinkedKit, gin.H{
+			"reference_id": *t.ReferenceID,
+		})
+		return false
+	}
+	return true
+}
+
+// ensureNotReconciliationAdjustment menolak perubahan penyesuaian hasil
+// rekonsiliasi stok karena penyesuaian terhubung ke audit rekonsiliasinya dan
+// mengubahnya akan memunculkan kembali selisih stok yang sudah dikoreksi.
+// Mengembalikan false jika response error sudah dikirim.
+func ensureNotReconciliationAdjustment(c *gin.Context, t models.Transaction) bool {
+	if t.ReferenceType != nil && *t.ReferenceType == constant.ReferenceTypeStockReconciliation {
+		utils.Error(c, http.StatusConflict, constant.MsgReconcileAdjustmentLocked, gin.H{
+			"error_code":   constant.ErrCodeReconciliationAdjustment,
+			"reference_id": *t.ReferenceID,
+		})
+		return false
+	}
+	return true
+}
+
+func toTransactionSnapshot(t models.Transaction) dto.TransactionSnapshot {
+	return dto.TransactionSnapshot{
+		ItemID:           t.ItemID,
+		Date:             t.Date.Format("2006-01-02"),
+		Quantity:         t.Quantity,
+		TransactionType:  t.TransactionType,
+		InspectionStatus: t.InspectionStatus,
+		Description:      t.Description,
+	}
+}
+
+// stockBalanceError membatalkan transaksi database saat perubahan membuat
+// stok kurang. Response dikirim setelah rollback lewat respondStockError.
+type stockBalanceError struct {
+	message string
+	details gin.H
+}
+
+func (e *stockBalanceError) Error() string {
+	return e.message
+}
+
+// lockItems mengunci baris barang dengan urutan tetap agar tidak deadlock.
+// Cek stok yang dijalankan setelahnya dalam tx yang sama melihat data terbaru.
+func lockItems(tx *gorm.DB, itemIDs ...string) (map[string]models.Item, error) {
+	var items []models.Item
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("item_id IN ?", itemIDs).
+		Order("item_id").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	locked := make(map[string]models.Item, len(items))
+	for _, item := range items {
+		locked[item.ItemID] = item
+	}
+	return locked, nil
+}
+
+// checkStockBalance menolak perubahan yang membuat saldo stok historis
+// negatif dengan mengembalikan stockBalanceError
+func checkStockBalance(tx *gorm.DB, item models.Item, excludeTransactionIDs []string, extra []services.StockMovement) error {
+	negative, err := services.CheckStockBalance(tx, item, excludeTransactionIDs, extra)
+	if err != nil {
+		return err
+	}
+	if negative != nil {
+		date := negative.Date.Format("2006-01-02")
+		return &stockBalanceError{
+			message: constant.MsgInsufficientStockAtDate,
+			details: gin.H{
+				"error_code": constant.ErrCodeNegativeStock,
+				"item_id":    item.ItemID,
+				"date":       date,
+				"balance":    negative.Balance,
+				"quantity":   fmt.Sprintf(constant.MsgNegativeStockDetail, negative.Balance, date),
+			},
+		}
+	}
+	return nil
+}
+
+// respondStockError mengirim response untuk stockBalanceError. Mengembalikan
+// true jika response sudah dikirim.
+func respondStockError(c *gin.Context, err error) bool {
+	var stockErr *stockBalanceError
+	if !errors.As(err, &stockErr) {
+		return false
+	}
+	utils.BadRequest(c, stockErr.message, stockErr.details)
+	return true
+}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constant "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"inventory_app_backend/pkg/firebase"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errAttachmentLimit = errors.New("attachment_limit")
+
+// attachmentOwner menunjuk pemilik lampiran, yaitu satu transaksi
+// (transaction_id) atau satu dokumen (reference_id)
+type attachmentOwner struct {
+	column string
+	id     string
+}
+
+func (h *TransactionHandler) GetTransactionAttachments(c *gin.Context) {
+	owner, ok := h.transactionOwner(c)
+	if !ok {
+		return
+	}
+	h.respondAttachments(c, owner, http.StatusOK, constant.MsgAttachmentsFetchSuccess)
+}
+
+func (h *TransactionHandler) AddTransactionAttachments(c *gin.Context) {
+	owner, ok := h.transactionOwner(c)
+	if !ok {
+		return
+	}
+	h.addAttachments(c, owner)
+}
+
+// GetDocumentAttachments mengambil lampiran dokumen, yaitu kelompok
+// transaksi dengan reference_id yang sama seperti batch impor
+func (h *TransactionHandler) GetDocumentAttachments(c *gin.Context) {
+	owner, ok := h.documentOwner(c)
+	if !ok {
+		return
+	}
+	h.respondAttachments(c, owner, http.StatusOK, constant.MsgAttachmentsFetchSuccess)
+}
+
+func (h *TransactionHandler) AddDocumentAttachments(c *gin.Context) {
+	owner, ok := h.documentOwner(c)
+	if !ok {
+		return
+	}
+	h.addAttachments(c, owner)
+}
+
+// DownloadTransactionAttachment mengarahkan ke signed URL yang membuat
+// browser mengunduh file dengan nama aslinya
+func (h *TransactionHandler) DownloadTransactionAttachment(c *gin.Context) {
+	var attachment models.TransactionAttachment
+	if err := h.DB.First(&attachment, "attachment_id = ?", c.Param("attachment_id")).Error; err != nil {
+		utils.NotFound(c, constant.MsgAttachmentNotFound)
+		return
+	}
+
+	path := firebase.ObjectPath(attachment.URL)
+	if path == "" {
+		c.Redirect(http.StatusFound, attachment.URL)
+		return
+	}
+
+	url, err := firebase.SignedDownloadURL(path, attachment.FileName)
+	if err != nil {
+		utils.ServerError(c, constant.MsgInternalServerError, err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+// DeleteTransactionAttachment menghapus lampiran. File di storage dihapus
+// setelah masa tenggang oleh RunPendingDeletions.
+func (h *TransactionHandler) DeleteTransactionAttachment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
+	var attachment models.TransactionAttachment
+	if err := h.DB.First(&attachment, "attachment_id = ?", c.Param("attachment_id")).Error; err != nil {
+		utils.NotFound(c, constant.MsgAttachmentNotFound)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&attachment).Error; err != nil {
+			return err
+		}
+		// File disimpan selama masa retensi lampiran, bukan masa tenggang gambar
+		if err := services.ScheduleAttachmentDeletion(tx, attachment); err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, userID.(string), constant.AuditActionAttachmentDelete,
+			constant.AuditEntityAttachment, attachment.AttachmentID, gin.H{
+				"transaction_id": attachment.TransactionID,
+				"reference_id":   attachment.ReferenceID,
+				"file_name":      attachment.FileName,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constant.MsgAttachmentFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constant.MsgAttachmentDeletedSuccess, nil)
+}
+
+// transactionOwner mengembalikan false jika response error sudah dikirim
+func (h *TransactionHandler) transactionOwner(c *gin.Context) (attachmentOwner, bool) {
+	var transaction models.Transaction
+	if err := h.DB.First(&transaction, "transaction_id = ?", c.Param("id")).Error; err != nil {
+		utils.NotFound(c, constant.MsgTransactionNotFound)
+		return attachmentOwner{}, false
+	}
+	return attachmentOwner{column: "transaction_id", id: transaction.TransactionID}, true
+}
+
+// documentOwner mengembalikan false jika response error sudah dikirim
+func (h *TransactionHandler) documentOwner(c *gin.Context) (attachmentOwner, bool) {
+	referenceID := c.Param("reference_id")
+
+	var total int64
+	if err := h.DB.Model(&models.Transaction{}).Where("reference_id = ?", referenceID).Count(&total).Error; err != nil {
+		utils.ServerError(c, constant.MsgInternalServerError, err)
+		return attachmentOwner{}, false
+	}
+	if total == 0 {
+		utils.NotFound(c, constant.MsgAttachmentDocumentMissing)
+		return attachmentOwner{}, false
+	}
+	return attachmentOwner{column: "reference_id", id: referenceID}, true
+}
+
+// addAttachments memvalidasi semua file sebelum upload agar satu file yang
+// ditolak tidak meninggalkan file lain di storage
+func (h *TransactionHandler) addAttachments(c *gin.Context, owner attachmentOwner) {
+	var req dto.AddTransactionAttachmentsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.BadRequest(c, constant.MsgValidationFailed, err)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
+	// Cek batas lampiran sebelum upload
+	var total int64
+	if err := h.DB.Model(&models.TransactionAttachment{}).Where(owner.column+" = ?", owner.id).Count(&total).Error; err != nil {
+		utils.ServerError(c, constant.MsgAttachmentFailed, err)
+		return
+	}
+	if int(total)+len(req.Files) > constant.MaxTransactionAttachments {
+		utils.BadRequest(c, constant.MsgAttachmentFailed, gin.H{
+			"files": fmt.Sprintf(constant.MsgAttachmentLimit, constant.MaxTransactionAttachments),
+		})
+		return
+	}
+
+	normalized := make([]*utils.NormalizedAttachment, len(req.Files))
+	validationErrors := map[string]string{}
+	for i, file := range req.Files {
+		attachment, rejection, err := utils.NormalizeAttachment(file)
+		if err != nil && rejection == nil {
+			utils.ServerError(c, constant.MsgAttachmentFailed, err)
+			return
+		}
+		if err != nil {
+			validationErrors[fmt.Sprintf("files.%d", i)] = rejection["file"]
+			if _, ok := validationErrors["error_code"]; !ok {
+				validationErrors["error_code"] = rejection["error_code"]
+			}
+			continue
+		}
+		normalized[i] = attachment
+	}
+	if len(validationErrors) > 0 {
+		utils.BadRequest(c, constant.MsgAttachmentInvalid, validationErrors)
+		return
+	}
+
+	var attachments []models.TransactionAttachment
+	for _, file := range normalized {
+		url, err := firebase.UploadBytes(file.Data, "attachments/"+uuid.New().String()+file.Ext, file.ContentType)
+		if err != nil {
+			h.discardAttachmentUploads(attachments)
+			utils.ServerError(c, constant.MsgAttachmentFailed, err)
+			return
+		}
+
+		attachment := models.TransactionAttachment{
+			AttachmentID: uuid.New().String(),
+			FileName:     file.FileName,
+			ContentType:  file.ContentType,
+			Size:         int64(len(file.Data)),
+			URL:          url,
+			UserID:       userID.(string),
+		}
+		if owner.column == "transaction_id" {
+			attachment.TransactionID = &owner.id
+		} else {
+			attachment.ReferenceID = &owner.id
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Kunci transaksi pemilik agar upload bersamaan tidak melewati batas
+		var transactions []models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(owner.column+" = ?", owner.id).
+			Find(&transactions).Error; err != nil {
+			return err
+		}
+		if len(transactions) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var total int64
+		if err := tx.Model(&models.TransactionAttachment{}).Where(owner.column+" = ?", owner.id).Count(&total).Error; err != nil {
+			return err
+		}
+		if int(total)+len(attachments) > constant.MaxTransactionAttachments {
+			return errAttachmentLimit
+		}
+
+		return tx.Create(&attachments).Error
+	})
+	if err != nil {
+		h.discardAttachmentUploads(attachments)
+	}
+	if errors.Is(err, errAttachmentLimit) {
+		utils.BadRequest(c, constant.MsgAttachmentFailed, gin.H{
+			"files": fmt.Sprintf(constant.MsgAttachmentLimit, constant.MaxTransactionAttachments),
+		})
+		return
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.NotFound(c, constant.MsgTransactionNotFound)
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constant.MsgAttachmentFailed, err)
+		return
+	}
+
+	h.respondAttachments(c, owner, http.StatusCreated, constant.MsgAttachmentsAddedSuccess)
+}
+
+// discardAttachmentUploads menjadwalkan penghapusan file yang sudah terupload
+// ketika lampiran gagal disimpan
+func (h *TransactionHandler) discardAttachmentUploads(attachments []models.TransactionAttachment) {
+	var urls []string
+	for _, attachment := range attachments {
+		urls = append(urls, attachment.URL)
+	}
+	if err := services.ScheduleImageDeletion(h.DB, urls...); err != nil {
+		log.Printf("Gagal menjadwalkan penghapusan lampiran: %v", err)
+	}
+}
+
+func (h *TransactionHandler) respondAttachments(c *gin.Context, owner attachmentOwner, status int, message string) {
+	var attachments []models.TransactionAttachment
+	if err := h.DB.Preload("User").
+		Where(owner.column+" = ?", owner.id).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		utils.ServerError(c, constant.MsgInternalServerError, err)
+		return
+	}
+	utils.Success(c, status, message, toAttachmentResponses(attachments))
+}
+
+// voidTransactionAttachments menjadwalkan penghapusan lampiran transaksi yang
+// dihapus sesuai retensi lampiran. Lampiran dokumen ikut dihapus jika
+// transaksi ini adalah transaksi terakhir dari dokumen tersebut. Baris
+// lampiran transaksi terhapus oleh ON DELETE CASCADE.
+func voidTransactionAttachments(tx *gorm.DB, transaction models.Transaction) error {
+	var attachments []models.TransactionAttachment
+	if err := tx.Where("transaction_id = ?", transaction.TransactionID).Find(&attachments).Error; err != nil {
+		return err
+	}
+
+	if transaction.ReferenceID != nil {
+		var remaining int64
+		if err := tx.Model(&models.Transaction{}).
+			Where("reference_id = ? AND transaction_id <> ?", *transaction.ReferenceID, transaction.TransactionID).
+			Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining == 0 {
+			var documentAttachments []models.TransactionAttachment
+			if err := tx.Where("reference_id = ?", *transaction.ReferenceID).Find(&documentAttachments).Error; err != nil {
+				return err
+			}
+			if len(documentAttachments) > 0 {
+				if err := tx.Delete(&documentAttachments).Error; err != nil {
+					return err
+				}
+			}
+			attachments = append(attachments, documentAttachments...)
+		}
+	}
+
+	return services.ScheduleAttachmentDeletion(tx, attachments...)
+}
+
+func toAttachmentResponses(attachments []models.TransactionAttachment) []dto.TransactionAttachmentResponse {
+	responses := []dto.TransactionAttachmentResponse{}
+	for _, attachment := range attachments {
+		resp := dto.TransactionAttachmentResponse{
+			AttachmentID: attachment.AttachmentID,
+			FileName:     attachment.FileName,
+			ContentType:  attachment.ContentType,
+			Size:         attachment.Size,
+			URL:          signImageURL(attachment.URL),
+			UserID:       attachment.UserID,
+			Username:     attachment.User.Username,
+			CreatedAt:    attachment.CreatedAt,
+		}
+		if attachment.TransactionID != nil {
+			resp.TransactionID = *attachment.TransactionID
+		}
+		if attachment.ReferenceID != nil {
+			resp.ReferenceID = *attachment.ReferenceID
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
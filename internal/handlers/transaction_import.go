@@ -0,0 +1,447 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	constant "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxImportRows = 1000
+
+var transactionImportColumns = []string{"item", "transaction_type", "quantity", "date", "description"}
+
+// importItemState menyimpan stok barang selama validasi agar baris-baris
+// dalam satu file divalidasi secara berurutan
+type importItemState struct {
+	item      models.Item
+	policy    string
+	history   []services.StockMovement
+	movements []services.StockMovement
+	delta     int
+}
+
+type transactionImporter struct {
+	db      *gorm.DB
+	items   map[string]*importItemState
+	periods map[string]*models.ClosedPeriod
+}
+
+// errImportStale membatalkan impor jika barang, stok atau periode berubah
+// antara validasi dan penyimpanan
+var errImportStale = errors.New("import_stale")
+
+func (h *TransactionHandler) DownloadImportTemplate(c *gin.Context) {
+	var req dto.ImportTemplateRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, constant.MsgValidationFailed, err)
+		return
+	}
+
+	example := []string{"Kabel HDMI", constant.TransactionTypeIn, "10", time.Now().Format("2006-01-02"), "Saldo awal"}
+
+	writeSpreadsheet(c, req.Format, constant.MsgImportTemplateFilename, transactionImportColumns, [][]string{example})
+}
+
+func (h *TransactionHandler) ImportTransactions(c *gin.Context) {
+	var req dto.ImportTransactionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.BadRequest(c, constant.MsgValidationFailed, err)
+		return
+	}
+
+	if req.File == nil {
+		utils.BadRequest(c, constant.MsgImportFileRequired, gin.H{
+			"file": constant.MsgFieldRequired,
+		})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
+	header, rows, ok := readImportFile(c, req.File, transactionImportColumns[:4])
+	if !ok {
+		return
+	}
+
+	importer := &transactionImporter{
+		db:      h.DB,
+		items:   make(map[string]*importItemState),
+		periods: make(map[string]*models.ClosedPeriod),
+	}
+
+	resp := dto.ImportTransactionResponse{DryRun: req.DryRun}
+	var transactions []models.Transaction
+	// rowIndexes memetakan transaksi ke baris hasil validasinya
+	var rowIndexes []int
+	for i, row := range rows {
+		if utils.IsBlankRow(row) {
+			continue
+		}
+
+		result, transaction, err := importer.validateRow(i+2, header, row)
+		if err != nil {
+			utils.ServerError(c, constant.MsgStockCheckFailed, err)
+			return
+		}
+
+		resp.TotalRows++
+		if len(result.Errors) > 0 {
+			resp.ErrorRows++
+		} else {
+			resp.ValidRows++
+			transactions = append(transactions, transaction)
+			rowIndexes = append(rowIndexes, len(resp.Rows))
+		}
+		resp.Rows = append(resp.Rows, result)
+	}
+
+	if resp.TotalRows == 0 {
+		utils.BadRequest(c, constant.MsgImportEmpty, gin.H{
+			"file": constant.MsgImportEmpty,
+		})
+		return
+	}
+
+	if req.DryRun {
+		utils.Success(c, http.StatusOK, constant.MsgImportValidated, resp)
+		return
+	}
+
+	// Semua baris harus valid, jika tidak maka tidak ada yang disimpan
+	if resp.ErrorRows > 0 {
+		utils.Error(c, http.StatusUnprocessableEntity, constant.MsgImportHasErrors, resp)
+		return
+	}
+
+	batchID := uuid.New().String()
+	referenceType := constant.ReferenceTypeImport
+	var conflicts map[int]map[string]string
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		// Validasi diulang pada barang yang dikunci karena stok atau periode
+		// bisa berubah sejak validasi di atas
+		var err error
+		conflicts, err = recheckImport(tx, transactions)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return errImportStale
+		}
+
+		for i := range transactions {
+			transactions[i].UserID = userID.(string)
+			transactions[i].ReferenceType = &referenceType
+			transactions[i].ReferenceID = &batchID
+			if err := tx.Create(&transactions[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return services.RecordAudit(tx, userID.(string), constant.AuditActionTransactionImport,
+			constant.AuditEntityImport, batchID, gin.H{
+				"file_name": req.File.Filename,
+				"rows":      len(transactions),
+			})
+	})
+	if errors.Is(err, errImportStale) {
+		for i, rowErrors := range conflicts {
+			resp.Rows[rowIndexes[i]].Errors = rowErrors
+		}
+		resp.ErrorRows = len(conflicts)
+		resp.ValidRows = resp.TotalRows - resp.ErrorRows
+		utils.Error(c, http.StatusConflict, constant.MsgImportStale, resp)
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constant.MsgImportFailed, err)
+		return
+	}
+
+	resp.Committed = true
+	resp.BatchID = batchID
+	utils.Success(c, http.StatusCreated, constant.MsgImportCommitted, resp)
+}
+
+func (imp *transactionImporter) validateRow(rowNumber int, header, row []string) (dto.ImportTransactionRow, models.Transaction, error) {
+	result := dto.ImportTransactionRow{
+		Row:             rowNumber,
+		TransactionType: strings.ToLower(utils.SpreadsheetCell(header, row, "transaction_type")),
+		Description:     utils.SpreadsheetCell(header, row, "description"),
+		Errors:          map[string]string{},
+	}
+	var transaction models.Transaction
+
+	state, err := imp.resolveItem(utils.SpreadsheetCell(header, row, "item"), result.Errors)
+	if err != nil {
+		return result, transaction, err
+	}
+	if state != nil {
+		result.ItemID = state.item.ItemID
+		result.ItemName = state.item.ItemName
+	}
+
+	if result.TransactionType != constant.TransactionTypeIn && result.TransactionType != constant.TransactionTypeOut {
+		result.Errors["transaction_type"] = constant.MsgImportInvalidType
+	}
+
+	quantity, err := strconv.Atoi(utils.SpreadsheetCell(header, row, "quantity"))
+	if err != nil || quantity < 1 {
+		result.Errors["quantity"] = constant.MsgImportInvalidQuantity
+	}
+	result.Quantity = quantity
+
+	date, err := utils.ParseSpreadsheetDate(utils.SpreadsheetCell(header, row, "date"))
+	if err != nil {
+		result.Errors["date"] = constant.MsgImportInvalidDate
+	} else {
+		result.Date = date.Format("2006-01-02")
+		period, err := imp.closedPeriod(date)
+		if err != nil {
+			return result, transaction, err
+		}
+		if period != nil {
+			result.Errors["date"] = fmt.Sprintf(constant.MsgPeriodLockedDetail,
+				period.StartDate.Format("2006-01-02"),
+				period.EndDate.Format("2006-01-02"))
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, transaction, nil
+	}
+
+	inspectionStatus := constant.InspectionStatusNone
+	if result.TransactionType == constant.TransactionTypeIn && state.item.RequiresInspection {
+		inspectionStatus = constant.InspectionStatusPending
+	}
+
+	movement := services.StockMovement{
+		Date:  date,
+		Delta: services.MovementDelta(result.TransactionType, inspectionStatus, quantity, 0),
+	}
+	if field, message := state.addMovement(movement); field != "" {
+		result.Errors[field] = message
+		return result, transaction, nil
+	}
+
+	transaction = models.Transaction{
+		TransactionID:    uuid.New().String(),
+		ItemID:           state.item.ItemID,
+		Date:             date,
+		Quantity:         quantity,
+		TransactionType:  result.TransactionType,
+		InspectionStatus: inspectionStatus,
+		Description:      result.Description,
+	}
+
+	return result, transaction, nil
+}
+
+// addMovement mengecek pergerakan terhadap stok saat ini dan saldo historis,
+// termasuk baris sebelumnya di file yang sama, lalu mencatatnya. Jika stok
+// tidak mencukupi dikembalikan kolom dan pesan error-nya.
+func (s *importItemState) addMovement(movement services.StockMovement) (string, string) {
+	if movement.Delta < 0 {
+		available := s.item.Stock + s.delta
+		if available+movement.Delta < 0 {
+			return "quantity", fmt.Sprintf(constant.MsgImportInsufficient, available)
+		}
+
+		if s.policy != constant.StockPolicyCurrentOnly {
+			after := append(append([]services.StockMovement{}, s.history...), s.movements...)
+			after = append(after, movement)
+			if negative := services.FirstNegativeBalance(s.history, after); negative != nil {
+				return "date", fmt.Sprintf(constant.MsgNegativeStockDetail,
+					negative.Balance, negative.Date.Format("2006-01-02"))
+			}
+		}
+	}
+	s.movements = append(s.movements, movement)
+	s.delta += movement.Delta
+	return "", ""
+}
+
+// recheckImport mengunci barang lalu mengulang cek periode, arsip, inspeksi
+// dan stok untuk setiap transaksi. Error per baris dikembalikan dengan indeks
+// transaksinya.
+func recheckImport(tx *gorm.DB, transactions []models.Transaction) (map[int]map[string]string, error) {
+	var itemIDs []string
+	seen := make(map[string]bool)
+	for _, transaction := range transactions {
+		if !seen[transaction.ItemID] {
+			seen[transaction.ItemID] = true
+			itemIDs = append(itemIDs, transaction.ItemID)
+		}
+	}
+
+	locked, err := lockItems(tx, itemIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []models.ItemType
+	var typeIDs []string
+	for _, item := range locked {
+		typeIDs = append(typeIDs, item.TypeID)
+	}
+	if err := tx.Where("type_id IN ?", typeIDs).Find(&types).Error; err != nil {
+		return nil, err
+	}
+	policies := make(map[string]string, len(types))
+	for _, itemType := range types {
+		policies[itemType.TypeID] = itemType.StockPolicy
+	}
+
+	states := make(map[string]*importItemState, len(locked))
+	periods := make(map[string]*models.ClosedPeriod)
+	conflicts := make(map[int]map[string]string)
+	for i, transaction := range transactions {
+		item, ok := locked[transaction.ItemID]
+		if !ok {
+			conflicts[i] = map[string]string{"item": constant.MsgImportItemNotFound}
+			continue
+		}
+		if item.ArchivedAt != nil {
+			conflicts[i] = map[string]string{"item": fmt.Sprintf(constant.MsgItemArchivedDetail, item.ItemName)}
+			continue
+		}
+
+		key := transaction.Date.Format("2006-01-02")
+		period, cached := periods[key]
+		if !cached {
+			if period, err = services.FindClosedPeriod(tx, transaction.Date); err != nil {
+				return nil, err
+			}
+			periods[key] = period
+		}
+		if period != nil {
+			conflicts[i] = map[string]string{"date": fmt.Sprintf(constant.MsgPeriodLockedDetail,
+				period.StartDate.Format("2006-01-02"),
+				period.EndDate.Format("2006-01-02"))}
+			continue
+		}
+
+		state, ok := states[item.ItemID]
+		if !ok {
+			history, err := services.ItemMovements(tx, item.ItemID, nil)
+			if err != nil {
+				return nil, err
+			}
+			state = &importItemState{item: item, policy: policies[item.TypeID], history: history}
+			states[item.ItemID] = state
+		}
+
+		// Kewajiban inspeksi mengikuti data barang terbaru
+		transactions[i].InspectionStatus = constant.InspectionStatusNone
+		if transaction.TransactionType == constant.TransactionTypeIn && item.RequiresInspection {
+			transactions[i].InspectionStatus = constant.InspectionStatusPending
+		}
+		transaction = transactions[i]
+
+		movement := services.StockMovement{
+			Date:  transaction.Date,
+			Delta: services.MovementDelta(transaction.TransactionType, transaction.InspectionStatus, transaction.Quantity, 0),
+		}
+		if field, message := state.addMovement(movement); field != "" {
+			conflicts[i] = map[string]string{field: message}
+		}
+	}
+	return conflicts, nil
+}
+
+// resolveItem mencari barang berdasarkan ID, barcode, SKU atau nama
+func (imp *transactionImporter) resolveItem(value string, rowErrors map[string]string) (*importItemState, error) {
+	if value == "" {
+		rowErrors["item"] = constant.MsgFieldRequired
+		return nil, nil
+	}
+
+	key := strings.ToLower(value)
+	if state, ok := imp.items[key]; ok {
+		return state, nil
+	}
+
+	var items []models.Item
+	query := imp.db.Preload("Type")
+	if _, err := uuid.Parse(value); err == nil {
+		query = query.Where("item_id = ?", value)
+	} else {
+		// Kolom item juga menerima barcode atau SKU
+		found, err := services.FindItemByCode(imp.db, value)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			query = query.Where("item_id = ?", found.ItemID)
+		} else {
+			query = query.Where("LOWER(item_name) = ?", key)
+		}
+	}
+	if err := query.Limit(2).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	switch len(items) {
+	case 0:
+		rowErrors["item"] = constant.MsgImportItemNotFound
+		return nil, nil
+	case 2:
+		rowErrors["item"] = constant.MsgImportItemAmbiguous
+		return nil, nil
+	}
+	if items[0].ArchivedAt != nil {
+		rowErrors["item"] = fmt.Sprintf(constant.MsgItemArchivedDetail, items[0].ItemName)
+		return nil, nil
+	}
+
+	// ID dan nama mengarah ke state yang sama agar saldo dihitung bersama
+	for _, state := range imp.items {
+		if state.item.ItemID == items[0].ItemID {
+			imp.items[key] = state
+			return state, nil
+		}
+	}
+
+	history, err := services.ItemMovements(imp.db, items[0].ItemID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &importItemState{
+		item:    items[0],
+		policy:  items[0].Type.StockPolicy,
+		history: history,
+	}
+	imp.items[key] = state
+	return state, nil
+}
+
+func (imp *transactionImporter) closedPeriod(date time.Time) (*models.ClosedPeriod, error) {
+	key := date.Format("2006-01-02")
+	if period, ok := imp.periods[key]; ok {
+		return period, nil
+	}
+
+	period, err := services.FindClosedPeriod(imp.db, date)
+	if err != nil {
+		return nil, err
+	}
+	imp.periods[key] = period
+	return period, nil
+}
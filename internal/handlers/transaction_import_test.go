@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	constant "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func expectImportLock(mock sqlmock.Sqlmock, stock int, requiresInspection bool) {
+	mock.ExpectQuery("SELECT \\* FROM `items` WHERE item_id IN .* ORDER BY item_id FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "item_name", "type_id", "stock", "requires_inspection"}).
+			AddRow("item-1", "Kabel HDMI", "type-1", stock, requiresInspection))
+	mock.ExpectQuery("SELECT \\* FROM `item_types` WHERE type_id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "stock_policy"}).
+			AddRow("type-1", constant.StockPolicyCurrentOnly))
+}
+
+func expectOpenPeriod(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT \\* FROM `closed_periods`").
+		WillReturnRows(sqlmock.NewRows([]string{"period_id"}))
+}
+
+func importTransaction(transactionType string, quantity int) models.Transaction {
+	return models.Transaction{
+		ItemID:           "item-1",
+		Date:             time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Quantity:         quantity,
+		TransactionType:  transactionType,
+		InspectionStatus: constant.InspectionStatusNone,
+	}
+}
+
+func TestRecheckImportStockChanged(t *testing.T) {
+	db, mock := newMockDB(t)
+	// Stok turun menjadi 3 sejak validasi
+	expectImportLock(mock, 3, false)
+	expectOpenPeriod(mock)
+	mock.ExpectQuery("FROM transactions AS t").
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+
+	transactions := []models.Transaction{
+		importTransaction(constant.TransactionTypeOut, 2),
+		importTransaction(constant.TransactionTypeOut, 2),
+	}
+	conflicts, err := recheckImport(db, transactions)
+	if err != nil {
+		t.Fatalf("recheckImport() error = %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[1]["quantity"] == "" {
+		t.Fatalf("conflicts = %v, want baris kedua kekurangan stok", conflicts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecheckImportPeriodClosed(t *testing.T) {
+	db, mock := newMockDB(t)
+	expectImportLock(mock, 10, false)
+	mock.ExpectQuery("SELECT \\* FROM `closed_periods`").
+		WillReturnRows(sqlmock.NewRows([]string{"period_id", "start_date", "end_date"}).
+			AddRow("period-1", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)))
+
+	// Periode tanggal yang sama hanya dicek sekali
+	conflicts, err := recheckImport(db, []models.Transaction{
+		importTransaction(constant.TransactionTypeIn, 5),
+		importTransaction(constant.TransactionTypeOut, 1),
+	})
+	if err != nil {
+		t.Fatalf("recheckImport() error = %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0]["date"] == "" || conflicts[1]["date"] == "" {
+		t.Fatalf("conflicts = %v, want semua baris di periode tertutup", conflicts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecheckImportFollowsInspectionFlag(t *testing.T) {
+	db, mock := newMockDB(t)
+	// Barang menjadi wajib inspeksi sejak validasi
+	expectImportLock(mock, 0, true)
+	expectOpenPeriod(mock)
+	mock.ExpectQuery("FROM transactions AS t").
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+
+	transactions := []models.Transaction{importTransaction(constant.TransactionTypeIn, 5)}
+	conflicts, err := recheckImport(db, transactions)
+	if err != nil {
+		t.Fatalf("recheckImport() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %v, want tidak ada", conflicts)
+	}
+	if transactions[0].InspectionStatus != constant.InspectionStatusPending {
+		t.Errorf("InspectionStatus = %q, want %q", transactions[0].InspectionStatus, constant.InspectionStatusPending)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	constant "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func TestEnsureNotReconciliationAdjustment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reconciliation := constant.ReferenceTypeStockReconciliation
+	importBatch := constant.ReferenceTypeImport
+	auditID := "audit-1"
+
+	tests := []struct {
+		name          string
+		referenceType *string
+		wantOK        bool
+	}{
+		{"transaksi biasa", nil, true},
+		{"transaksi impor", &importBatch, true},
+		{"penyesuaian rekonsiliasi", &reconciliation, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			ok := ensureNotReconciliationAdjustment(c, models.Transaction{
+				ReferenceType: tt.referenceType,
+				ReferenceID:   &auditID,
+			})
+			if ok != tt.wantOK {
+				t.Fatalf("ensureNotReconciliationAdjustment() = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if w.Code != http.StatusConflict {
+					t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+				}
+				if !strings.Contains(w.Body.String(), constant.ErrCodeReconciliationAdjustment) {
+					t.Errorf("body = %s, want error_code %s", w.Body.String(), constant.ErrCodeReconciliationAdjustment)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateTransactionRechecksInspectionInTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, mock := newMockDB(t)
+
+	date := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	transactionColumns := []string{"transaction_id", "item_id", "date", "quantity", "transaction_type", "inspection_status"}
+
+	// Saat dibaca transaksi masih menunggu inspeksi
+	mock.ExpectQuery("SELECT \\* FROM `transactions` WHERE transaction_id = ").
+		WillReturnRows(sqlmock.NewRows(transactionColumns).
+			AddRow("trx-1", "item-1", date, 10, constant.TransactionTypeIn, constant.InspectionStatusPending))
+	mock.ExpectQuery("SELECT \\* FROM `items`").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "item_name", "requires_inspection"}).
+			AddRow("item-1", "Kabel HDMI", true))
+	mock.ExpectQuery("SELECT \\* FROM `closed_periods`").
+		WillReturnRows(sqlmock.NewRows([]string{"period_id"}))
+
+	// Inspeksi selesai sebelum baris dikunci
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `transactions` WHERE transaction_id = .* FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows(transactionColumns).
+			AddRow("trx-1", "item-1", date, 10, constant.TransactionTypeIn, constant.InspectionStatusInspected))
+	mock.ExpectQuery("SELECT \\* FROM `items` WHERE item_id IN .* FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "stock"}).AddRow("item-1", 10))
+	mock.ExpectRollback()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "user-1")
+	c.Params = gin.Params{{Key: "id", Value: "trx-1"}}
+	c.Request = httptest.NewRequest(http.MethodPut, "/transactions/trx-1", strings.NewReader(`{"quantity":8}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h := &TransactionHandler{DB: db}
+	h.UpdateTransaction(c)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusConflict, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), constant.MsgTransactionInspected) {
+		t.Errorf("body = %s, want pesan %q", w.Body.String(), constant.MsgTransactionInspected)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
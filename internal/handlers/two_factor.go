@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/dto"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
+	"inventory_app_backend/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LoginTwoFactor menukar token tantangan dan kode 2FA dengan token sesi.
+// Kode salah dihitung sebagai login gagal sehingga ikut terkena kunci login.
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req dto.LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	claims, err := utils.ValidateChallengeToken(req.ChallengeToken)
+	if err != nil {
+		utils.Unauthorized(c, constants.MsgTwoFactorChallengeInvalid)
+		return
+	}
+
+	var user models.User
+	if err := h.DB.Where("user_id = ?", claims.UserID).First(&user).Error; err != nil ||
+		!user.IsActive || !user.TOTPEnabled {
+		utils.Unauthorized(c, constants.MsgTwoFactorChallengeInvalid)
+		return
+	}
+	if user.PasswordChangedAt != nil &&
+		(claims.IssuedAt == nil || claims.IssuedAt.Before(*user.PasswordChangedAt)) {
+		utils.Unauthorized(c, constants.MsgTwoFactorChallengeInvalid)
+		return
+	}
+
+	remaining, err := services.LoginLockRemaining(h.DB, user.Username, c.ClientIP())
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	if remaining > 0 {
+		respondLoginLocked(c, remaining)
+		return
+	}
+
+	ok, err := services.VerifySecondFactor(h.DB, user, req.Code)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	if !ok {
+		if err := services.RecordLoginFailure(h.DB, user.Username, c.ClientIP()); err != nil {
+			utils.ServerError(c, constants.MsgInternalServerError, err)
+			return
+		}
+		utils.Unauthorized(c, constants.MsgTwoFactorInvalidCode)
+		return
+	}
+
+	h.completeLogin(c, user)
+}
+
+func (h *AuthHandler) GetTwoFactorStatus(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	required, err := services.RoleRequiresTwoFactor(h.DB, user.Role)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+
+	var remaining int64
+	if user.TOTPEnabled {
+		remaining, err = services.RemainingRecoveryCodes(h.DB, user.UserID)
+		if err != nil {
+			utils.ServerError(c, constants.MsgInternalServerError, err)
+			return
+		}
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgTwoFactorStatusFetched, dto.TwoFactorStatusResponse{
+		Enabled:                user.TOTPEnabled,
+		Required:               required && user.AuthProvider == constants.AuthProviderLocal,
+		RemainingRecoveryCodes: remaining,
+	})
+}
+
+// SetupTwoFactor membuat secret baru yang belum aktif. 2FA baru aktif setelah
+// user membuktikan authenticator-nya bekerja lewat EnableTwoFactor.
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorSetupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if user.AuthProvider != constants.AuthProviderLocal {
+		utils.BadRequest(c, constants.MsgTwoFactorManagedBySSO, nil)
+		return
+	}
+	if user.TOTPEnabled {
+		utils.Error(c, http.StatusConflict, constants.MsgTwoFactorAlreadyEnabled, nil)
+		return
+	}
+	if err := utils.ComparePassword(user.Password, req.Password); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"password": constants.MsgPasswordCurrentWrong,
+		})
+		return
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+	uri := utils.TOTPProvisioningURI(services.TOTPIssuer(), user.Username, secret)
+	qrCode, err := utils.QRCodeDataURI(uri)
+	if err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+
+	if err := h.DB.Model(&user).Update("totp_secret", secret).Error; err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgTwoFactorSetupSuccess, dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: uri,
+		QRCode:          qrCode,
+	})
+}
+
+// EnableTwoFactor mengaktifkan 2FA setelah kode dari authenticator cocok lalu
+// mengirim kode pemulihan
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if user.TOTPEnabled {
+		utils.Error(c, http.StatusConflict, constants.MsgTwoFactorAlreadyEnabled, nil)
+		return
+	}
+	if user.TOTPSecret == nil {
+		utils.BadRequest(c, constants.MsgTwoFactorNotSetUp, nil)
+		return
+	}
+
+	step, valid := utils.ValidateTOTP(*user.TOTPSecret, req.Code, time.Now())
+	if !valid {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"code": constants.MsgTwoFactorInvalidCode,
+		})
+		return
+	}
+
+	var codes []string
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Updates(map[string]interface{}{
+			"totp_enabled":    true,
+			"totp_enabled_at": time.Now(),
+			"totp_last_step":  step,
+		}).Error; err != nil {
+			return err
+		}
+
+		var err error
+		codes, err = services.GenerateRecoveryCodes(tx, user.UserID)
+		if err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, user.UserID, constants.AuditActionTwoFactorEnable,
+			constants.AuditEntityUser, user.UserID, gin.H{
+				"username": user.Username,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgTwoFactorEnabled, dto.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// DisableTwoFactor mematikan 2FA milik sendiri. Butuh password dan kode 2FA,
+// dan ditolak jika role mewajibkan 2FA.
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		utils.BadRequest(c, constants.MsgTwoFactorNotEnabled, nil)
+		return
+	}
+
+	required, err := services.RoleRequiresTwoFactor(h.DB, user.Role)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return
+	}
+	if required {
+		utils.Forbidden(c, constants.MsgTwoFactorRequiredByRole)
+		return
+	}
+
+	if err := utils.ComparePassword(user.Password, req.Password); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"password": constants.MsgPasswordCurrentWrong,
+		})
+		return
+	}
+	if !h.verifySecondFactor(c, user, req.Code) {
+		return
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := clearTwoFactor(tx, user.UserID); err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, user.UserID, constants.AuditActionTwoFactorDisable,
+			constants.AuditEntityUser, user.UserID, gin.H{
+				"username": user.Username,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgTwoFactorDisabled, nil)
+}
+
+// RegenerateRecoveryCodes membuat kode pemulihan baru dan membatalkan kode
+// lama
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constants.MsgValidationFailed, err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		utils.BadRequest(c, constants.MsgTwoFactorNotEnabled, nil)
+		return
+	}
+	if !h.verifySecondFactor(c, user, req.Code) {
+		return
+	}
+
+	var codes []string
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = services.GenerateRecoveryCodes(tx, user.UserID)
+		if err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, user.UserID, constants.AuditActionRecoveryCodesNew,
+			constants.AuditEntityUser, user.UserID, gin.H{
+				"username": user.Username,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, constants.MsgRecoveryCodesRegenerated, dto.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// ResetTwoFactor dipakai admin ketika user kehilangan authenticator dan kode
+// pemulihannya. Jika role mewajibkan 2FA, user harus setup ulang setelah
+// login.
+func (h *AuthHandler) ResetTwoFactor(c *gin.Context) {
+	adminID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constants.MsgInvalidSession)
+		return
+	}
+
+	var user models.User
+	if err := h.DB.Where("user_id = ?", c.Param("id")).First(&user).Error; err != nil {
+		utils.NotFound(c, constants.MsgUserNotFound)
+		return
+	}
+
+	if !user.TOTPEnabled && user.TOTPSecret == nil {
+		utils.BadRequest(c, constants.MsgTwoFactorNotEnabled, nil)
+		return
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := clearTwoFactor(tx, user.UserID); err != nil {
+			return err
+		}
+		return services.RecordAudit(tx, adminID.(string), constants.AuditActionTwoFactorReset,
+			constants.AuditEntityUser, user.UserID, gin.H{
+				"username": user.Username,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constants.MsgTwoFactorSaveFailed, err)
+		return
+	}
+	user.TOTPEnabled = false
+
+	utils.Success(c, http.StatusOK, constants.MsgTwoFactorReset, toUserResponse(user))
+}
+
+// verifySecondFactor mengecek kode TOTP atau kode pemulihan. Mengembalikan
+// false jika response error sudah dikirim.
+func (h *AuthHandler) verifySecondFactor(c *gin.Context, user models.User, code string) bool {
+	ok, err := services.VerifySecondFactor(h.DB, user, code)
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		return false
+	}
+	if !ok {
+		utils.BadRequest(c, constants.MsgValidationFailed, gin.H{
+			"code": constants.MsgTwoFactorInvalidCode,
+		})
+		return false
+	}
+	return true
+}
+
+func clearTwoFactor(tx *gorm.DB, userID string) error {
+	if err := tx.Model(&models.User{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"totp_secret":     nil,
+		"totp_enabled":    false,
+		"totp_enabled_at": nil,
+		"totp_last_step":  0,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error
+}
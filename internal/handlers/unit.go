@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"fmt"
 	constant "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/dto"
 	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 	"net/http"
 	"time"
@@ -29,8 +29,12 @@ func (h *UnitHandler) CreateUnit(c *gin.Context) {
 	// Cek duplikat
 	var existingUnit models.Unit
 	if err := h.DB.Where("unit_name = ?", req.UnitName).First(&existingUnit).Error; err == nil {
+		detail := constant.MsgUnitExistsDetail
+		if existingUnit.ArchivedAt != nil {
+			detail = constant.MsgUnitExistsArchived
+		}
 		utils.Error(c, http.StatusConflict, constant.MsgUnitExists, gin.H{
-			"unit_name": constant.MsgUnitExistsDetail,
+			"unit_name": detail,
 		})
 		return
 	}
@@ -47,10 +51,7 @@ func (h *UnitHandler) CreateUnit(c *gin.Context) {
 		return
 	}
 
-	resp := dto.UnitResponse{
-		UnitID:   newUnit.UnitID,
-		UnitName: newUnit.UnitName,
-	}
+	resp := toUnitResponse(newUnit)
 
 	utils.Success(c, http.StatusCreated, constant.MsgUnitCreatedSuccess, resp)
 }
@@ -88,10 +89,7 @@ func (h *UnitHandler) UpdateUnit(c *gin.Context) {
 		return
 	}
 
-	resp := dto.UnitResponse{
-		UnitID:   unit.UnitID,
-		UnitName: unit.UnitName,
-	}
+	resp := toUnitResponse(unit)
 
 	utils.Success(c, http.StatusOK, constant.MsgUnitUpdatedSuccess, resp)
 }
@@ -114,6 +112,7 @@ func (h *UnitHandler) GetAllUnits(c *gin.Context) {
 
 	// Query
 	query := h.DB.Model(&models.Unit{}).Order("created_at DESC")
+	query = filterArchived(query, req.Archived, "archived_at")
 
 	if req.Search != "" {
 		query = query.Where("unit_name LIKE ?", "%"+req.Search+"%")
@@ -134,10 +133,7 @@ func (h *UnitHandler) GetAllUnits(c *gin.Context) {
 	// Mapping
 	var unitResponses []dto.UnitResponse
 	for _, unit := range units {
-		unitResponses = append(unitResponses, dto.UnitResponse{
-			UnitID:   unit.UnitID,
-			UnitName: unit.UnitName,
-		})
+		unitResponses = append(unitResponses, toUnitResponse(unit))
 	}
 
 	// Pagination
@@ -159,33 +155,77 @@ func (h *UnitHandler) GetAllUnits(c *gin.Context) {
 	utils.Success(c, http.StatusOK, constant.MsgUnitsFetchSuccess, resp)
 }
 
+// DeleteUnit mengarsipkan satuan. Barang yang sudah memakai satuan ini tidak
+// berubah, tetapi barang baru tidak bisa memilihnya.
 func (h *UnitHandler) DeleteUnit(c *gin.Context) {
+	h.setUnitArchived(c, true)
+}
+
+func (h *UnitHandler) RestoreUnit(c *gin.Context) {
+	h.setUnitArchived(c, false)
+}
+
+func (h *UnitHandler) setUnitArchived(c *gin.Context, archive bool) {
 	unitID := c.Param("id")
 
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.Unauthorized(c, constant.MsgInvalidSession)
+		return
+	}
+
 	var unit models.Unit
 	if err := h.DB.Where("unit_id = ?", unitID).First(&unit).Error; err != nil {
 		utils.NotFound(c, constant.MsgUnitNotFound)
 		return
 	}
 
-	// Cek apakah unit digunakan di items
-	var count int64
-	if err := h.DB.Model(&models.Item{}).Where("unit_id = ?", unitID).Count(&count).Error; err != nil {
-		utils.ServerError(c, constant.MsgItemTypeDeleteFailed, err)
+	action, message := constant.AuditActionUnitArchive, constant.MsgUnitArchivedOK
+	var archivedAt *time.Time
+	if archive {
+		if unit.ArchivedAt != nil {
+			utils.Error(c, http.StatusConflict, constant.MsgUnitArchived, gin.H{
+				"unit_id": constant.MsgUnitArchived,
+			})
+			return
+		}
+		now := time.Now()
+		archivedAt = &now
+	} else {
+		if unit.ArchivedAt == nil {
+			utils.Error(c, http.StatusConflict, constant.MsgUnitNotArchived, gin.H{
+				"unit_id": constant.MsgUnitNotArchived,
+			})
+			return
+		}
+		action, message = constant.AuditActionUnitRestore, constant.MsgUnitRestoredOK
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Unit{}).
+			Where("unit_id = ?", unit.UnitID).
+			Update("archived_at", archivedAt).Error; err != nil {
+			return err
+		}
+
+		return services.RecordAudit(tx, userID.(string), action,
+			constant.AuditEntityUnit, unit.UnitID, gin.H{
+				"unit_name": unit.UnitName,
+			})
+	})
+	if err != nil {
+		utils.ServerError(c, constant.MsgUnitUpdateFailed, err)
 		return
 	}
 
-	if count > 0 {
-		utils.Error(c, http.StatusConflict, constant.MsgItemTypeInUse, gin.H{
-			"unit_id": fmt.Sprintf(constant.MsgUnitInUseDetail, count),
-		})
-		return
-	}
+	unit.ArchivedAt = archivedAt
+	utils.Success(c, http.StatusOK, message, toUnitResponse(unit))
+}
 
-	if err := h.DB.Delete(&unit).Error; err != nil {
-		utils.ServerError(c, constant.MsgUnitDeleteFailed, err)
-		return
+func toUnitResponse(unit models.Unit) dto.UnitResponse {
+	return dto.UnitResponse{
+		UnitID:     unit.UnitID,
+		UnitName:   unit.UnitName,
+		ArchivedAt: formatArchivedAt(unit.ArchivedAt),
 	}
-
-	utils.Success(c, http.StatusOK, constant.MsgUnitDeletedSuccess, nil)
 }
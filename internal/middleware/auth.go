@@ -1,14 +1,27 @@
 package middleware
 
 import (
+	"errors"
 	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func Auth() gin.HandlerFunc {
+// APIKeyHeader adalah header untuk autentikasi integrasi dengan API key
+const APIKeyHeader = "X-API-Key"
+
+func Auth(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Integrasi mesin memakai API key, bukan JWT
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			authenticateAPIKey(c, db, key)
+			return
+		}
+
 		tokenString := c.GetHeader("Authorization")
 
 		if tokenString == "" {
@@ -25,9 +38,74 @@ func Auth() gin.HandlerFunc {
 			return
 		}
 
+		// User dicek di setiap request agar user yang dinonaktifkan atau
+		// direset passwordnya langsung kehilangan akses. Role juga diambil
+		// dari database sehingga perubahan role tidak menunggu login ulang.
+		var user models.User
+		if err := db.Select("user_id", "role", "is_active", "must_change_password", "password_changed_at", "totp_enabled", "auth_provider").
+			First(&user, "user_id = ?", claims.UserID).Error; err != nil {
+			utils.Unauthorized(c, constants.MsgTokenInvalid)
+			c.Abort()
+			return
+		}
+		if !user.IsActive {
+			utils.Unauthorized(c, constants.MsgUserInactive)
+			c.Abort()
+			return
+		}
+		if user.PasswordChangedAt != nil &&
+			(claims.IssuedAt == nil || claims.IssuedAt.Before(*user.PasswordChangedAt)) {
+			utils.Unauthorized(c, constants.MsgTokenInvalid)
+			c.Abort()
+			return
+		}
+
+		// User yang role-nya mewajibkan 2FA tetap bisa login agar bisa
+		// melakukan setup, tapi fitur lain ditahan oleh RequirePermission.
+		// Faktor kedua user SSO diatur di identity provider.
+		setupRequired := false
+		if !user.TOTPEnabled && user.AuthProvider == constants.AuthProviderLocal {
+			required, err := services.RoleRequiresTwoFactor(db, user.Role)
+			if err != nil {
+				utils.ServerError(c, constants.MsgInternalServerError, err)
+				c.Abort()
+				return
+			}
+			setupRequired = required
+		}
+
 		// Set user context
-		c.Set("userID", claims.UserID)
-		c.Set("role", claims.Role)
+		c.Set("userID", user.UserID)
+		c.Set("role", user.Role)
+		c.Set("mustChangePassword", user.MustChangePassword)
+		c.Set("twoFactorSetupRequired", setupRequired)
 		c.Next()
 	}
 }
+
+// authenticateAPIKey mengisi context dengan service account pemilik key.
+// Izin request dibatasi pada izin API key, bukan izin role.
+func authenticateAPIKey(c *gin.Context, db *gorm.DB, key string) {
+	apiKey, err := services.AuthenticateAPIKey(db, key, c.ClientIP())
+	if errors.Is(err, services.ErrAPIKeyInvalid) {
+		utils.Unauthorized(c, constants.MsgAPIKeyInvalid)
+		c.Abort()
+		return
+	}
+	if errors.Is(err, services.ErrAPIKeyIPNotAllowed) {
+		utils.Forbidden(c, constants.MsgAPIKeyIPNotAllowed)
+		c.Abort()
+		return
+	}
+	if err != nil {
+		utils.ServerError(c, constants.MsgInternalServerError, err)
+		c.Abort()
+		return
+	}
+
+	c.Set("userID", apiKey.UserID)
+	c.Set("role", apiKey.User.Role)
+	c.Set("apiKeyID", apiKey.KeyID)
+	c.Set("apiKeyPermissions", services.APIKeyPermissions(apiKey))
+	c.Next()
+}
@@ -2,28 +2,73 @@ package middleware
 
 import (
 	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/services"
 	"inventory_app_backend/internal/utils"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func RoleAllowed(allowedRoles ...string) gin.HandlerFunc {
+// RequirePermission hanya meneruskan request jika role user memiliki izin
+// yang diminta. Izin role dibaca dari tabel role_permissions, sedangkan
+// request dengan API key hanya memakai izin milik key tersebut.
+func RequirePermission(db *gorm.DB, permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := c.Get("role")
-		if !exists {
+		// Password yang direset admin wajib diganti sebelum fitur lain dipakai
+		if c.GetBool("mustChangePassword") {
+			utils.Error(c, http.StatusForbidden, constants.MsgPasswordChangeRequired, gin.H{
+				"error_code": constants.ErrCodePasswordChangeRequired,
+			})
+			c.Abort()
+			return
+		}
+		// Role yang mewajibkan 2FA harus setup 2FA terlebih dahulu
+		if c.GetBool("twoFactorSetupRequired") {
+			utils.Error(c, http.StatusForbidden, constants.MsgTwoFactorSetupRequired, gin.H{
+				"error_code": constants.ErrCodeTwoFactorSetupRequired,
+			})
+			c.Abort()
+			return
+		}
+
+		// Izin khusus admin tetap dicek terhadap role walaupun tercatat di
+		// role_permissions role lain
+		if constants.AdminOnlyPermissions[permission] && c.GetString("role") != constants.RoleAdmin {
 			utils.Forbidden(c, constants.MsgForbiddenError)
 			c.Abort()
 			return
 		}
 
-		for _, role := range allowedRoles {
-			if userRole == role {
-				c.Next()
+		if scopes, ok := c.Get("apiKeyPermissions"); ok {
+			if !scopes.(map[string]bool)[permission] {
+				utils.Forbidden(c, constants.MsgForbiddenError)
+				c.Abort()
 				return
 			}
+			c.Next()
+			return
+		}
+
+		userRole, exists := c.Get("role")
+		if !exists {
+			utils.Forbidden(c, constants.MsgForbiddenError)
+			c.Abort()
+			return
+		}
+
+		allowed, err := services.HasPermission(db, userRole.(string), permission)
+		if err != nil {
+			utils.ServerError(c, constants.MsgInternalServerError, err)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			utils.Forbidden(c, constants.MsgForbiddenError)
+			c.Abort()
+			return
 		}
 
-		utils.Forbidden(c, constants.MsgForbiddenError)
-		c.Abort()
+		c.Next()
 	}
 }
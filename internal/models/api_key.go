@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+type APIKey struct {
+	KeyID      string `gorm:"primaryKey;type:char(36)"`
+	Name       string `gorm:"type:varchar(100);not null"`
+	KeyPrefix  string `gorm:"type:varchar(16);not null"`
+	KeyHash    string `gorm:"type:char(64);not null;unique"`
+	UserID     string `gorm:"type:char(36);not null"`
+	AllowedIPs string `gorm:"column:allowed_ips;type:text"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	LastUsedIP string `gorm:"column:last_used_ip;type:varchar(45)"`
+	RevokedAt  *time.Time
+	CreatedBy  string `gorm:"type:char(36);not null"`
+	CreatedAt  time.Time
+
+	User        User               `gorm:"foreignKey:UserID;references:UserID"`
+	Permissions []APIKeyPermission `gorm:"foreignKey:KeyID;references:KeyID"`
+}
+
+type APIKeyPermission struct {
+	KeyID      string `gorm:"primaryKey;type:char(36)"`
+	Permission string `gorm:"primaryKey;type:varchar(100)"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type AuditLog struct {
+	AuditID    string `gorm:"primaryKey;type:char(36)"`
+	UserID     string `gorm:"type:char(36);not null"`
+	Action     string `gorm:"not null"`
+	EntityType string `gorm:"not null"`
+	EntityID   string `gorm:"type:char(36)"`
+	Details    string `gorm:"type:text"`
+	CreatedAt  time.Time
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:UserID"`
+}
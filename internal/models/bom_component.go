@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type BOMComponent struct {
+	BOMComponentID  string `gorm:"primaryKey;type:char(36)"`
+	KitItemID       string `gorm:"type:char(36);not null;uniqueIndex:idx_bom_kit_component"`
+	ComponentItemID string `gorm:"type:char(36);not null;uniqueIndex:idx_bom_kit_component"`
+	Quantity        int    `gorm:"not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	// Relations
+	Component Item `gorm:"foreignKey:ComponentItemID;references:ItemID"`
+}
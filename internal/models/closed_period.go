@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+type ClosedPeriod struct {
+	PeriodID     string    `gorm:"primaryKey;type:char(36)"`
+	StartDate    time.Time `gorm:"type:date;not null"`
+	EndDate      time.Time `gorm:"type:date;not null"`
+	Status       string    `gorm:"type:ENUM('closed', 'reopened');not null;default:'closed'"`
+	Note         string
+	ClosedBy     string  `gorm:"type:char(36);not null"`
+	ReopenedBy   *string `gorm:"type:char(36)"`
+	ReopenReason string
+	ReopenedAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+type Inspection struct {
+	InspectionID     string  `gorm:"primaryKey;type:char(36)"`
+	TransactionID    string  `gorm:"type:char(36);not null"`
+	ItemID           string  `gorm:"type:char(36);not null"`
+	ReleasedQuantity int     `gorm:"not null;default:0"`
+	RejectedQuantity int     `gorm:"not null;default:0"`
+	Disposition      *string `gorm:"type:ENUM('return', 'scrap')"`
+	Notes            string
+	UserID           string `gorm:"type:char(36);not null"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	// Relations
+	Transaction Transaction `gorm:"foreignKey:TransactionID;references:TransactionID"`
+	Item        Item        `gorm:"foreignKey:ItemID;references:ItemID"`
+	User        User        `gorm:"foreignKey:UserID;references:UserID"`
+}
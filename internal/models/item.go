@@ -5,17 +5,25 @@ import (
 )
 
 type Item struct {
-	ItemID       string `gorm:"primaryKey;type:char(36)"`
-	TypeID       string `gorm:"type:char(36);not null"`
-	UnitID       string `gorm:"type:char(36);not null"`
-	ItemName     string `gorm:"not null"`
-	Stock        int    `gorm:"not null;default:0"`
-	MinimumStock int    `gorm:"not null;default:0"`
-	Image        string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ItemID             string  `gorm:"primaryKey;type:char(36)"`
+	TypeID             string  `gorm:"type:char(36);not null"`
+	UnitID             string  `gorm:"type:char(36);not null"`
+	ItemName           string  `gorm:"not null"`
+	SKU                *string `gorm:"column:sku;type:varchar(64);unique"`
+	Stock              int     `gorm:"not null;default:0"`
+	MinimumStock       int     `gorm:"not null;default:0"`
+	QuarantineStock    int     `gorm:"not null;default:0"`
+	RequiresInspection bool    `gorm:"not null;default:false"`
+	Image              string
+	ImageThumbnail     string
+	ArchivedAt         *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 
 	// Relations
-	Type ItemType `gorm:"foreignKey:TypeID;references:TypeID"`
-	Unit Unit     `gorm:"foreignKey:UnitID;references:UnitID"`
+	Type            ItemType             `gorm:"foreignKey:TypeID;references:TypeID"`
+	Unit            Unit                 `gorm:"foreignKey:UnitID;references:UnitID"`
+	Barcodes        []ItemBarcode        `gorm:"foreignKey:ItemID;references:ItemID"`
+	AttributeValues []ItemAttributeValue `gorm:"foreignKey:ItemID;references:ItemID"`
+	Images          []ItemImage          `gorm:"foreignKey:ItemID;references:ItemID"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+type ItemAttributeValue struct {
+	ItemID      string `gorm:"primaryKey;type:char(36)"`
+	AttributeID string `gorm:"primaryKey;type:char(36)"`
+	Value       string `gorm:"type:varchar(255);not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	// Relations
+	Attribute ItemTypeAttribute `gorm:"foreignKey:AttributeID;references:AttributeID"`
+}
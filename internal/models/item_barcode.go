@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type ItemBarcode struct {
+	BarcodeID string `gorm:"primaryKey;type:char(36)"`
+	ItemID    string `gorm:"type:char(36);not null"`
+	Code      string `gorm:"type:varchar(64);unique;not null"`
+	Symbology string `gorm:"type:ENUM('ean13', 'code128', 'custom');not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type ItemImage struct {
+	ImageID      string `gorm:"primaryKey;type:char(36)"`
+	ItemID       string `gorm:"type:char(36);not null;index"`
+	URL          string `gorm:"column:url;type:varchar(255);not null"`
+	MediumURL    string `gorm:"column:medium_url;type:varchar(255);not null"`
+	ThumbnailURL string `gorm:"column:thumbnail_url;type:varchar(255);not null"`
+	SortOrder    int    `gorm:"not null;default:0"`
+	IsPrimary    bool   `gorm:"not null;default:false"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
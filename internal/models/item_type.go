@@ -5,8 +5,11 @@ import (
 )
 
 type ItemType struct {
-	TypeID    string `gorm:"primaryKey;type:char(36)"`
-	TypeName  string `gorm:"unique;not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	TypeID      string  `gorm:"primaryKey;type:char(36)"`
+	ParentID    *string `gorm:"type:char(36)"`
+	TypeName    string  `gorm:"unique;not null"`
+	StockPolicy string  `gorm:"type:ENUM('strict', 'current_only');not null;default:'strict'"`
+	ArchivedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
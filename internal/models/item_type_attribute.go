@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type ItemTypeAttribute struct {
+	AttributeID string `gorm:"primaryKey;type:char(36)"`
+	TypeID      string `gorm:"type:char(36);not null;uniqueIndex:idx_type_attribute_name"`
+	Name        string `gorm:"type:varchar(64);not null;uniqueIndex:idx_type_attribute_name"`
+	Label       string `gorm:"type:varchar(100);not null"`
+	DataType    string `gorm:"type:ENUM('text', 'number', 'enum', 'date');not null"`
+	Options     string `gorm:"type:text"`
+	Required    bool   `gorm:"not null;default:false"`
+	SortOrder   int    `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+type LoginThrottle struct {
+	ThrottleKey   string `gorm:"primaryKey;type:varchar(300)"`
+	Failures      int    `gorm:"not null;default:0"`
+	LockedUntil   *time.Time
+	LastFailureAt *time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type OIDCLoginState struct {
+	State        string `gorm:"primaryKey;type:varchar(64)"`
+	CodeVerifier string `gorm:"type:varchar(128);not null"`
+	Nonce        string `gorm:"type:varchar(64);not null"`
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+// TableName diperlukan karena GORM memecah singkatan OIDC menjadi o_id_c
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
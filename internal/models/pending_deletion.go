@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+type PendingDeletion struct {
+	DeletionID  string    `gorm:"primaryKey;type:char(36)"`
+	ObjectPath  string    `gorm:"type:varchar(512);not null"`
+	DeleteAfter time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+type Role struct {
+	Name             string `gorm:"primaryKey;type:varchar(50)"`
+	Description      string `gorm:"type:varchar(255)"`
+	IsSystem         bool   `gorm:"not null;default:false"`
+	RequireTwoFactor bool   `gorm:"not null;default:false"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	Permissions []RolePermission `gorm:"foreignKey:RoleName;references:Name"`
+}
+
+type RolePermission struct {
+	RoleName   string `gorm:"primaryKey;type:varchar(50)"`
+	Permission string `gorm:"primaryKey;type:varchar(100)"`
+}
+
+type Permission struct {
+	Permission  string `gorm:"primaryKey;type:varchar(100)"`
+	Description string `gorm:"type:varchar(255);not null"`
+}
@@ -5,15 +5,18 @@ import (
 )
 
 type Transaction struct {
-	TransactionID   string    `gorm:"primaryKey;type:char(36)"`
-	ItemID          string    `gorm:"type:char(36);not null"`
-	Date            time.Time `gorm:"type:date;not null"`
-	Quantity        int       `gorm:"not null"`
-	TransactionType string    `gorm:"type:ENUM('in', 'out');not null"`
-	Description     string
-	UserID          string `gorm:"type:char(36);not null"`
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	TransactionID    string    `gorm:"primaryKey;type:char(36)"`
+	ItemID           string    `gorm:"type:char(36);not null"`
+	Date             time.Time `gorm:"type:date;not null"`
+	Quantity         int       `gorm:"not null"`
+	TransactionType  string    `gorm:"type:ENUM('in', 'out');not null"`
+	InspectionStatus string    `gorm:"type:ENUM('none', 'pending', 'inspected');not null;default:'none'"`
+	Description      string
+	ReferenceType    *string `gorm:"type:varchar(50)"`
+	ReferenceID      *string `gorm:"type:char(36)"`
+	UserID           string  `gorm:"type:char(36);not null"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 
 	// Relations
 	Item Item `gorm:"foreignKey:ItemID;references:ItemID"`
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// TransactionAttachment adalah lampiran (surat jalan, foto, faktur) milik
+// satu transaksi atau satu dokumen, yaitu kelompok transaksi dengan
+// reference_id yang sama seperti batch impor
+type TransactionAttachment struct {
+	AttachmentID  string  `gorm:"primaryKey;type:char(36)"`
+	TransactionID *string `gorm:"type:char(36);index"`
+	ReferenceID   *string `gorm:"type:char(36);index"`
+	FileName      string  `gorm:"type:varchar(255);not null"`
+	ContentType   string  `gorm:"type:varchar(100);not null"`
+	Size          int64   `gorm:"not null"`
+	URL           string  `gorm:"column:url;type:varchar(255);not null"`
+	UserID        string  `gorm:"type:char(36);not null"`
+	CreatedAt     time.Time
+
+	User User `gorm:"foreignKey:UserID;references:UserID"`
+}
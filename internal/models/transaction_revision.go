@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type TransactionRevision struct {
+	RevisionID    string `gorm:"primaryKey;type:char(36)"`
+	TransactionID string `gorm:"type:char(36);not null"`
+	OldValues     string `gorm:"type:text;not null"`
+	NewValues     string `gorm:"type:text;not null"`
+	Reason        string
+	UserID        string `gorm:"type:char(36);not null"`
+	CreatedAt     time.Time
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:UserID"`
+}
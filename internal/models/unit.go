@@ -5,8 +5,9 @@ import (
 )
 
 type Unit struct {
-	UnitID    string `gorm:"primaryKey;type:char(36)"`
-	UnitName  string `gorm:"unique;not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	UnitID     string `gorm:"primaryKey;type:char(36)"`
+	UnitName   string `gorm:"unique;not null"`
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
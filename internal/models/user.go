@@ -5,11 +5,21 @@ import (
 )
 
 type User struct {
-	UserID    string `gorm:"primaryKey;type:char(36)"`
-	Username  string `gorm:"unique;not null"`
-	Password  string `gorm:"not null"`
-	FullName  string
-	Role      string `gorm:"type:ENUM('admin', 'warehouse_admin', 'warehouse_manager')"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	UserID             string `gorm:"primaryKey;type:char(36)"`
+	Username           string `gorm:"unique;not null"`
+	Password           string `gorm:"not null"`
+	FullName           string
+	Role               string `gorm:"type:varchar(50);not null"`
+	IsServiceAccount   bool   `gorm:"not null;default:false"`
+	IsActive           bool   `gorm:"not null;default:true"`
+	MustChangePassword bool   `gorm:"not null;default:false"`
+	PasswordChangedAt  *time.Time
+	TOTPSecret         *string `gorm:"type:varchar(64)"`
+	TOTPEnabled        bool    `gorm:"not null;default:false"`
+	TOTPEnabledAt      *time.Time
+	TOTPLastStep       int64   `gorm:"not null;default:0"`
+	AuthProvider       string  `gorm:"type:varchar(20);not null;default:local"`
+	ExternalSubject    *string `gorm:"type:varchar(255);unique"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
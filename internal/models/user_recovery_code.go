@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+type UserRecoveryCode struct {
+	CodeID    string `gorm:"primaryKey;type:char(36)"`
+	UserID    string `gorm:"type:char(36);not null"`
+	CodeHash  string `gorm:"type:char(64);not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
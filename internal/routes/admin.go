@@ -8,16 +8,41 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func setupAdminRoutes(router *gin.Engine, h *handlers.AuthHandler) {
+func setupAdminRoutes(router *gin.Engine, h *handlers.AuthHandler, a *handlers.AuditLogHandler, r *handlers.ReconciliationHandler, ro *handlers.RoleHandler, k *handlers.APIKeyHandler) {
 	adminGroup := router.Group("/admin")
-	adminGroup.Use(
-		middleware.Auth(),
-		middleware.RoleAllowed(constants.RoleAdmin),
-	)
+	adminGroup.Use(middleware.Auth(h.DB))
+
+	userRoutes := adminGroup.Group("")
+	userRoutes.Use(middleware.RequirePermission(h.DB, constants.PermUsersManage))
+	{
+		userRoutes.GET("/users", h.GetUsers)
+		userRoutes.GET("/users/:id", h.GetUserByID)
+		userRoutes.POST("/users", h.CreateUser)
+		userRoutes.PUT("/users/:id", h.UpdateUser)
+		userRoutes.POST("/users/:id/unlock", h.UnlockUser)
+		userRoutes.POST("/users/:id/deactivate", h.DeactivateUser)
+		userRoutes.POST("/users/:id/activate", h.ActivateUser)
+		userRoutes.POST("/users/:id/reset-2fa", h.ResetTwoFactor)
+	}
+
+	roleRoutes := adminGroup.Group("")
+	roleRoutes.Use(middleware.RequirePermission(ro.DB, constants.PermRolesManage))
 	{
-		adminGroup.GET("/users", h.GetUsers)
-		adminGroup.GET("/users/:id", h.GetUserByID)
-		adminGroup.POST("/users", h.CreateUser)
-		adminGroup.PUT("/users/:id", h.UpdateUser)
+		roleRoutes.GET("/permissions", ro.GetPermissions)
+		roleRoutes.GET("/roles", ro.GetRoles)
+		roleRoutes.POST("/roles", ro.CreateRole)
+		roleRoutes.PUT("/roles/:name", ro.UpdateRole)
+		roleRoutes.DELETE("/roles/:name", ro.DeleteRole)
 	}
+
+	apiKeyRoutes := adminGroup.Group("")
+	apiKeyRoutes.Use(middleware.RequirePermission(k.DB, constants.PermAPIKeysManage))
+	{
+		apiKeyRoutes.GET("/api-keys", k.GetAPIKeys)
+		apiKeyRoutes.POST("/api-keys", k.CreateAPIKey)
+		apiKeyRoutes.DELETE("/api-keys/:id", k.RevokeAPIKey)
+	}
+
+	adminGroup.GET("/audit-logs", middleware.RequirePermission(a.DB, constants.PermAuditLogsRead), a.GetAuditLogs)
+	adminGroup.POST("/stock/reconcile", middleware.RequirePermission(r.DB, constants.PermStockReconcile), r.ReconcileStock)
 }
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"inventory_app_backend/internal/handlers"
+	"inventory_app_backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,5 +11,23 @@ func setupAuthRoutes(router *gin.Engine, h *handlers.AuthHandler) {
 	authGroup := router.Group("/auth")
 	{
 		authGroup.POST("/login", h.Login)
+		authGroup.POST("/login/2fa", h.LoginTwoFactor)
+		authGroup.GET("/oidc/login", h.OIDCLogin)
+		authGroup.POST("/oidc/callback", h.OIDCCallback)
+	}
+
+	// Akun sendiri, tetap bisa diakses saat password wajib diganti atau
+	// 2FA wajib disetup
+	meRoutes := authGroup.Group("/me")
+	meRoutes.Use(middleware.Auth(h.DB))
+	{
+		meRoutes.GET("", h.GetProfile)
+		meRoutes.PUT("", h.UpdateProfile)
+		meRoutes.PUT("/password", h.ChangePassword)
+		meRoutes.GET("/2fa", h.GetTwoFactorStatus)
+		meRoutes.POST("/2fa/setup", h.SetupTwoFactor)
+		meRoutes.POST("/2fa/enable", h.EnableTwoFactor)
+		meRoutes.POST("/2fa/disable", h.DisableTwoFactor)
+		meRoutes.POST("/2fa/recovery-codes", h.RegenerateRecoveryCodes)
 	}
 }
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/handlers"
+	"inventory_app_backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupInspectionRoutes(router *gin.Engine, h *handlers.InspectionHandler) {
+	inspectionRoutes := router.Group("/inspections")
+	inspectionRoutes.Use(middleware.Auth(h.DB))
+
+	readRoutes := inspectionRoutes.Group("")
+	readRoutes.Use(middleware.RequirePermission(h.DB, constants.PermInspectionsRead))
+	{
+		readRoutes.GET("", h.GetAllInspections)
+		readRoutes.GET("/pending", h.GetPendingInspections)
+	}
+
+	writeRoutes := inspectionRoutes.Group("")
+	writeRoutes.Use(middleware.RequirePermission(h.DB, constants.PermInspectionsWrite))
+	{
+		writeRoutes.POST("", h.CreateInspection)
+	}
+}
@@ -10,23 +10,43 @@ import (
 
 func setupItemRoutes(router *gin.Engine, h *handlers.ItemHandler) {
 	itemRoutes := router.Group("/items")
-	itemRoutes.Use(middleware.Auth())
+	itemRoutes.Use(middleware.Auth(h.DB))
 
-	// Read-only routes accessible to admin, warehouse_admin, and warehouse_manager
 	readRoutes := itemRoutes.Group("")
-	readRoutes.Use(middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin, constants.RoleWarehouseManager))
+	readRoutes.Use(middleware.RequirePermission(h.DB, constants.PermItemsRead))
 	{
 		readRoutes.GET("", h.GetAllItems)
+		readRoutes.GET("/lookup", h.LookupItem)
 		readRoutes.GET("/:id", h.GetItemByID)
 		readRoutes.GET("/low-stock", h.GetLowStockItems)
+		readRoutes.GET("/export", h.ExportItems)
+		readRoutes.POST("/labels", h.PrintLabels)
+		readRoutes.GET("/:id/bom", h.GetBOM)
+		readRoutes.GET("/:id/images", h.GetItemImages)
 	}
 
-	// Write routes accessible only to admin and warehouse_admin
 	writeRoutes := itemRoutes.Group("")
-	writeRoutes.Use(middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin))
+	writeRoutes.Use(middleware.RequirePermission(h.DB, constants.PermItemsWrite))
 	{
 		writeRoutes.POST("", h.CreateItem)
+		writeRoutes.POST("/import", h.ImportItems)
 		writeRoutes.PUT("/:id", h.UpdateItem)
 		writeRoutes.DELETE("/:id", h.DeleteItem)
+		writeRoutes.POST("/:id/restore", h.RestoreItem)
+		writeRoutes.POST("/:id/barcodes", h.AddBarcode)
+		writeRoutes.DELETE("/:id/barcodes/:barcode_id", h.DeleteBarcode)
+		writeRoutes.POST("/:id/images", h.AddItemImage)
+		writeRoutes.PUT("/:id/images/order", h.ReorderItemImages)
+		writeRoutes.PUT("/:id/images/:image_id/primary", h.SetPrimaryItemImage)
+		writeRoutes.DELETE("/:id/images/:image_id", h.DeleteItemImage)
+		writeRoutes.PUT("/:id/bom", h.SetBOM)
+		writeRoutes.POST("/:id/assemble", h.AssembleKit)
+		writeRoutes.POST("/:id/disassemble", h.DisassembleKit)
+	}
+
+	mergeRoutes := itemRoutes.Group("")
+	mergeRoutes.Use(middleware.RequirePermission(h.DB, constants.PermItemsMerge))
+	{
+		mergeRoutes.POST("/merge", h.MergeItems)
 	}
 }
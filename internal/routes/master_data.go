@@ -10,25 +10,33 @@ import (
 
 func setupMasterDataRoutes(router *gin.Engine, h *handlers.ItemTypeHandler, u *handlers.UnitHandler) {
 	masterDataRoutes := router.Group("/master-data")
-	masterDataRoutes.Use(middleware.Auth())
+	masterDataRoutes.Use(middleware.Auth(h.DB))
 
-	// Read-only routes accessible to admin, warehouse_admin, and warehouse_manager
 	readRoutes := masterDataRoutes.Group("")
-	readRoutes.Use(middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin, constants.RoleWarehouseManager))
+	readRoutes.Use(middleware.RequirePermission(h.DB, constants.PermMasterDataRead))
 	{
 		readRoutes.GET("/item-types", h.GetAllItemTypes)
+		readRoutes.GET("/item-types/:id/attributes", h.GetItemTypeAttributes)
 		readRoutes.GET("/units", u.GetAllUnits)
 	}
 
-	// Write routes accessible only to admin and warehouse_admin
 	writeRoutes := masterDataRoutes.Group("")
-	writeRoutes.Use(middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin))
+	writeRoutes.Use(middleware.RequirePermission(h.DB, constants.PermMasterDataWrite))
 	{
 		writeRoutes.POST("/item-types", h.CreateItemType)
 		writeRoutes.PUT("/item-types/:id", h.UpdateItemType)
 		writeRoutes.DELETE("/item-types/:id", h.DeleteItemType)
+		writeRoutes.POST("/item-types/:id/restore", h.RestoreItemType)
+		writeRoutes.PUT("/item-types/:id/attributes", h.SetItemTypeAttributes)
 		writeRoutes.POST("/units", u.CreateUnit)
 		writeRoutes.PUT("/units/:id", u.UpdateUnit)
 		writeRoutes.DELETE("/units/:id", u.DeleteUnit)
+		writeRoutes.POST("/units/:id/restore", u.RestoreUnit)
+	}
+
+	stockPolicyRoutes := masterDataRoutes.Group("")
+	stockPolicyRoutes.Use(middleware.RequirePermission(h.DB, constants.PermMasterDataStockPolicy))
+	{
+		stockPolicyRoutes.PUT("/item-types/:id/stock-policy", h.UpdateStockPolicy)
 	}
 }
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/handlers"
+	"inventory_app_backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupPeriodRoutes(router *gin.Engine, h *handlers.PeriodHandler) {
+	periodRoutes := router.Group("/periods")
+	periodRoutes.Use(middleware.Auth(h.DB))
+
+	readRoutes := periodRoutes.Group("")
+	readRoutes.Use(middleware.RequirePermission(h.DB, constants.PermPeriodsRead))
+	{
+		readRoutes.GET("", h.GetAllPeriods)
+	}
+
+	manageRoutes := periodRoutes.Group("")
+	manageRoutes.Use(middleware.RequirePermission(h.DB, constants.PermPeriodsManage))
+	{
+		manageRoutes.POST("", h.ClosePeriod)
+	}
+
+	reopenRoutes := periodRoutes.Group("")
+	reopenRoutes.Use(middleware.RequirePermission(h.DB, constants.PermPeriodsReopen))
+	{
+		reopenRoutes.POST("/:id/reopen", h.ReopenPeriod)
+	}
+}
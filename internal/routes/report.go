@@ -1,7 +1,9 @@
 package routes
 
 import (
+	constants "inventory_app_backend/internal/constant"
 	"inventory_app_backend/internal/handlers"
+	"inventory_app_backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,7 +11,7 @@ import (
 func setupReportRoutes(router *gin.Engine, h *handlers.ReportHandler) {
 
 	reportRoutes := router.Group("/reports")
-
+	reportRoutes.Use(middleware.Auth(h.DB), middleware.RequirePermission(h.DB, constants.PermReportsExport))
 	{
 		reportRoutes.GET("/items", h.GenerateItemReport)
 		reportRoutes.GET("/transactions", h.GenerateTransactionReport)
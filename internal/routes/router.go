@@ -1,8 +1,11 @@
 package routes
 
 import (
+	"inventory_app_backend/internal/config"
 	"inventory_app_backend/internal/handlers"
 	"inventory_app_backend/internal/middleware"
+	"log"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,10 +18,30 @@ func SetupRouter(
 	transactionHandler *handlers.TransactionHandler,
 	reportHandler *handlers.ReportHandler,
 	summaryHandler *handlers.SummaryHandler,
+	inspectionHandler *handlers.InspectionHandler,
+	periodHandler *handlers.PeriodHandler,
+	auditLogHandler *handlers.AuditLogHandler,
+	reconciliationHandler *handlers.ReconciliationHandler,
+	roleHandler *handlers.RoleHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
 
 ) *gin.Engine {
 	router := gin.New()
 
+	// IP klien dari X-Forwarded-For hanya dipercaya jika request datang dari
+	// proxy di TRUSTED_PROXIES. Dipakai untuk allow-list IP API key dan kunci
+	// login per IP. Wajib diatur jika API berjalan di belakang reverse proxy,
+	// tanpa pengaturan ini kunci login per IP tidak aktif.
+	var trustedProxies []string
+	if proxies := config.Get("TRUSTED_PROXIES"); proxies != "" {
+		for _, proxy := range strings.Split(proxies, ",") {
+			trustedProxies = append(trustedProxies, strings.TrimSpace(proxy))
+		}
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("TRUSTED_PROXIES tidak valid: %v", err)
+	}
+
 	// Global middleware
 	router.Use(
 		middleware.Recovery(),
@@ -32,11 +55,13 @@ func SetupRouter(
 
 	// Setup route groups
 	setupAuthRoutes(router, authHandler)
-	setupAdminRoutes(router, authHandler)
+	setupAdminRoutes(router, authHandler, auditLogHandler, reconciliationHandler, roleHandler, apiKeyHandler)
 	setupItemRoutes(router, itemHandler)
 	setupMasterDataRoutes(router, itemTypeHandler, unitHandler)
 	setupTransactionRoutes(router, transactionHandler)
 	setupReportRoutes(router, reportHandler)
 	setupSummaryRoutes(router, summaryHandler)
+	setupInspectionRoutes(router, inspectionHandler)
+	setupPeriodRoutes(router, periodHandler)
 	return router
 }
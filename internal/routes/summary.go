@@ -10,7 +10,7 @@ import (
 
 func setupSummaryRoutes(router *gin.Engine, h *handlers.SummaryHandler) {
 	summary := router.Group("/summary")
-	summary.Use(middleware.Auth(), middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin, constants.RoleWarehouseManager))
+	summary.Use(middleware.Auth(h.DB), middleware.RequirePermission(h.DB, constants.PermSummaryRead))
 	{
 		summary.GET("/inventory", h.GetInventorySummary)
 	}
@@ -10,18 +10,28 @@ import (
 
 func setupTransactionRoutes(router *gin.Engine, h *handlers.TransactionHandler) {
 	transactionRoutes := router.Group("/transactions")
-	transactionRoutes.Use(middleware.Auth())
+	transactionRoutes.Use(middleware.Auth(h.DB))
 
 	readRoutes := transactionRoutes.Group("")
-	readRoutes.Use(middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin, constants.RoleWarehouseManager))
+	readRoutes.Use(middleware.RequirePermission(h.DB, constants.PermTransactionsRead))
 	{
 		readRoutes.GET("", h.GetAllTransactions)
+		readRoutes.GET("/:id/revisions", h.GetTransactionRevisions)
+		readRoutes.GET("/:id/attachments", h.GetTransactionAttachments)
+		readRoutes.GET("/documents/:reference_id/attachments", h.GetDocumentAttachments)
+		readRoutes.GET("/attachments/:attachment_id/download", h.DownloadTransactionAttachment)
 	}
 
 	writeRoutes := transactionRoutes.Group("")
-	writeRoutes.Use(middleware.RoleAllowed(constants.RoleAdmin, constants.RoleWarehouseAdmin))
+	writeRoutes.Use(middleware.RequirePermission(h.DB, constants.PermTransactionsWrite))
 	{
 		writeRoutes.POST("", h.CreateTransaction)
+		writeRoutes.GET("/import/template", h.DownloadImportTemplate)
+		writeRoutes.POST("/import", h.ImportTransactions)
+		writeRoutes.PUT("/:id", h.UpdateTransaction)
 		writeRoutes.DELETE("/:id", h.DeleteTransaction)
+		writeRoutes.POST("/:id/attachments", h.AddTransactionAttachments)
+		writeRoutes.POST("/documents/:reference_id/attachments", h.AddDocumentAttachments)
+		writeRoutes.DELETE("/attachments/:attachment_id", h.DeleteTransactionAttachment)
 	}
 }
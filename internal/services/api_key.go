@@ -0,0 +1,138 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"inventory_app_backend/internal/models"
+	"net"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// apiKeyPrefix memudahkan mengenali API key yang bocor di log atau repo
+	apiKeyPrefix = "inv_"
+
+	// apiKeyTouchInterval membatasi update last_used_at agar request beruntun
+	// dari integrasi tidak menulis ke database setiap kali
+	apiKeyTouchInterval = time.Minute
+)
+
+var (
+	ErrAPIKeyInvalid      = errors.New("api_key_invalid")
+	ErrAPIKeyIPNotAllowed = errors.New("api_key_ip_not_allowed")
+)
+
+// GenerateAPIKey membuat API key baru. Hanya prefix dan hash yang disimpan,
+// kunci asli ditampilkan sekali saat dibuat.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, key[:12], HashAPIKey(key), nil
+}
+
+// HashAPIKey menghitung hash SHA-256 dari API key. Kunci berisi 256 bit acak
+// sehingga tidak perlu hash lambat seperti bcrypt.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAllowedIPs memvalidasi daftar IP atau CIDR. Mengembalikan entri yang
+// tidak valid beserta indeksnya.
+func ParseAllowedIPs(entries []string) ([]string, map[int]string) {
+	var allowed []string
+	invalid := map[int]string{}
+	for i, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			allowed = append(allowed, entry)
+			continue
+		}
+		if net.ParseIP(entry) != nil {
+			allowed = append(allowed, entry)
+			continue
+		}
+		invalid[i] = entry
+	}
+	return allowed, invalid
+}
+
+// AuthenticateAPIKey mencari API key yang aktif dan mengecek IP pemanggil.
+// Kunci yang tidak ada, dicabut, kedaluwarsa atau milik service account yang
+// dinonaktifkan sama-sama ErrAPIKeyInvalid.
+func AuthenticateAPIKey(db *gorm.DB, key, clientIP string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	err := db.Preload("User").Preload("Permissions").
+		Where("key_hash = ?", HashAPIKey(key)).
+		First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil || !apiKey.User.IsActive ||
+		(apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now)) {
+		return nil, ErrAPIKeyInvalid
+	}
+	if !ipAllowed(apiKey.AllowedIPs, clientIP) {
+		return nil, ErrAPIKeyIPNotAllowed
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyTouchInterval || apiKey.LastUsedIP != clientIP {
+		if err := db.Model(&models.APIKey{}).
+			Where("key_id = ?", apiKey.KeyID).
+			Updates(map[string]interface{}{"last_used_at": now, "last_used_ip": clientIP}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return &apiKey, nil
+}
+
+// APIKeyPermissions mengubah izin API key menjadi map untuk pengecekan
+func APIKeyPermissions(apiKey *models.APIKey) map[string]bool {
+	permissions := make(map[string]bool, len(apiKey.Permissions))
+	for _, permission := range apiKey.Permissions {
+		permissions[permission.Permission] = true
+	}
+	return permissions
+}
+
+// ipAllowed mengecek IP pemanggil terhadap daftar IP/CIDR yang dipisah koma.
+// Daftar kosong berarti semua IP diizinkan.
+func ipAllowed(allowedIPs, clientIP string) bool {
+	if strings.TrimSpace(allowedIPs) == "" {
+		return true
+	}
+
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, entry := range strings.Split(allowedIPs, ",") {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// TypeAttributes mengambil skema atribut jenis barang sesuai urutan tampil
+func TypeAttributes(db *gorm.DB, typeID string) ([]models.ItemTypeAttribute, error) {
+	var attributes []models.ItemTypeAttribute
+	err := db.Where("type_id = ?", typeID).
+		Order("sort_order ASC").
+		Find(&attributes).Error
+	return attributes, err
+}
+
+// AttributeOptions membaca daftar opsi atribut enum
+func AttributeOptions(attribute models.ItemTypeAttribute) []string {
+	var options []string
+	if attribute.Options != "" {
+		_ = json.Unmarshal([]byte(attribute.Options), &options)
+	}
+	return options
+}
+
+// ParseAttributeInput membaca objek JSON atribut dari form barang. Angka
+// diubah menjadi teks, null atau string kosong berarti menghapus nilai.
+func ParseAttributeInput(raw string) (map[string]string, error) {
+	var input map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &input); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(input))
+	for name, value := range input {
+		switch v := value.(type) {
+		case nil:
+			values[name] = ""
+		case string:
+			values[name] = strings.TrimSpace(v)
+		case float64:
+			values[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("attribute %s: unsupported value", name)
+		}
+	}
+	return values, nil
+}
+
+// NormalizeAttributeValue memvalidasi nilai sesuai tipe data atribut dan
+// mengembalikan bentuk yang disimpan. Pesan error kosong berarti valid.
+func NormalizeAttributeValue(attribute models.ItemTypeAttribute, value string) (string, string) {
+	switch attribute.DataType {
+	case constants.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", constants.MsgItemAttributeNumber
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), ""
+	case constants.AttributeTypeDate:
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", constants.MsgItemAttributeDate
+		}
+		return date.Format("2006-01-02"), ""
+	case constants.AttributeTypeEnum:
+		options := AttributeOptions(attribute)
+		for _, option := range options {
+			if option == value {
+				return value, ""
+			}
+		}
+		return "", fmt.Sprintf(constants.MsgItemAttributeEnum, strings.Join(options, ", "))
+	}
+
+	if utf8.RuneCountInString(value) > 255 {
+		return "", constants.MsgItemAttributeTooLong
+	}
+	return value, ""
+}
+
+// ResolveAttributeValues menggabungkan nilai tersimpan (current, per nama
+// atribut) dengan input lalu memvalidasinya terhadap skema jenis barang.
+// Hasilnya nilai final per attribute_id dan error per field.
+func ResolveAttributeValues(attributes []models.ItemTypeAttribute, current, input map[string]string) (map[string]string, map[string]string) {
+	values := map[string]string{}
+	errs := map[string]string{}
+	known := map[string]bool{}
+
+	for _, attribute := range attributes {
+		known[attribute.Name] = true
+		field := "attributes." + attribute.Name
+
+		value, ok := input[attribute.Name]
+		if !ok {
+			value = current[attribute.Name]
+		}
+		if value == "" {
+			if attribute.Required {
+				errs[field] = constants.MsgItemAttributeRequired
+			}
+			continue
+		}
+
+		normalized, msg := NormalizeAttributeValue(attribute, value)
+		if msg != "" {
+			errs[field] = msg
+			continue
+		}
+		values[attribute.AttributeID] = normalized
+	}
+
+	for name := range input {
+		if !known[name] {
+			errs["attributes."+name] = constants.MsgItemAttributeUnknown
+		}
+	}
+
+	return values, errs
+}
+
+// SaveItemAttributes mengganti seluruh nilai atribut barang
+func SaveItemAttributes(tx *gorm.DB, itemID string, values map[string]string) error {
+	if err := tx.Where("item_id = ?", itemID).Delete(&models.ItemAttributeValue{}).Error; err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	rows := make([]models.ItemAttributeValue, 0, len(values))
+	for attributeID, value := range values {
+		rows = append(rows, models.ItemAttributeValue{
+			ItemID:      itemID,
+			AttributeID: attributeID,
+			Value:       value,
+		})
+	}
+	return tx.Create(&rows).Error
+}
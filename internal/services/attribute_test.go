@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"testing"
+)
+
+var testAttributes = []models.ItemTypeAttribute{
+	{AttributeID: "attr-color", Name: "warna", DataType: constants.AttributeTypeEnum, Options: `["hitam","putih"]`, Required: true},
+	{AttributeID: "attr-length", Name: "panjang", DataType: constants.AttributeTypeNumber},
+	{AttributeID: "attr-date", Name: "garansi", DataType: constants.AttributeTypeDate},
+}
+
+func TestResolveAttributeValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    map[string]string
+		input      map[string]string
+		wantValues map[string]string
+		wantErrors map[string]string
+	}{
+		{
+			name:       "nilai baru dinormalisasi",
+			input:      map[string]string{"warna": "hitam", "panjang": "2.50", "garansi": "2025-01-31"},
+			wantValues: map[string]string{"attr-color": "hitam", "attr-length": "2.5", "attr-date": "2025-01-31"},
+			wantErrors: map[string]string{},
+		},
+		{
+			name:       "nilai lama dipakai jika tidak dikirim",
+			current:    map[string]string{"warna": "putih", "panjang": "3"},
+			input:      map[string]string{"garansi": "2025-02-01"},
+			wantValues: map[string]string{"attr-color": "putih", "attr-length": "3", "attr-date": "2025-02-01"},
+			wantErrors: map[string]string{},
+		},
+		{
+			name:       "string kosong menghapus nilai",
+			current:    map[string]string{"warna": "putih", "panjang": "3"},
+			input:      map[string]string{"panjang": ""},
+			wantValues: map[string]string{"attr-color": "putih"},
+			wantErrors: map[string]string{},
+		},
+		{
+			name:       "atribut wajib kosong",
+			input:      map[string]string{},
+			wantValues: map[string]string{},
+			wantErrors: map[string]string{"attributes.warna": constants.MsgItemAttributeRequired},
+		},
+		{
+			name:       "nilai tidak valid dan atribut tidak dikenal",
+			input:      map[string]string{"warna": "merah", "panjang": "dua", "garansi": "31-01-2025", "berat": "1"},
+			wantValues: map[string]string{},
+			wantErrors: map[string]string{
+				"attributes.warna":   fmt.Sprintf(constants.MsgItemAttributeEnum, "hitam, putih"),
+				"attributes.panjang": constants.MsgItemAttributeNumber,
+				"attributes.garansi": constants.MsgItemAttributeDate,
+				"attributes.berat":   constants.MsgItemAttributeUnknown,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, errs := ResolveAttributeValues(testAttributes, tt.current, tt.input)
+			assertStringMap(t, "values", values, tt.wantValues)
+			assertStringMap(t, "errors", errs, tt.wantErrors)
+		})
+	}
+}
+
+func TestParseAttributeInput(t *testing.T) {
+	values, err := ParseAttributeInput(`{"warna": " hitam ", "panjang": 2.5, "garansi": null}`)
+	if err != nil {
+		t.Fatalf("ParseAttributeInput() error = %v", err)
+	}
+	assertStringMap(t, "values", values, map[string]string{"warna": "hitam", "panjang": "2.5", "garansi": ""})
+
+	for _, raw := range []string{`[1]`, `{"warna": true}`, `{"warna": {"a": 1}}`} {
+		if _, err := ParseAttributeInput(raw); err == nil {
+			t.Errorf("ParseAttributeInput(%s) harus error", raw)
+		}
+	}
+}
+
+func assertStringMap(t *testing.T, name string, got, want map[string]string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", name, got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s[%s] = %q, want %q", name, key, got[key], value)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"encoding/json"
+	"inventory_app_backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecordAudit menyimpan jejak audit, details akan disimpan sebagai JSON
+func RecordAudit(db *gorm.DB, userID, action, entityType, entityID string, details interface{}) error {
+	return recordAudit(db, uuid.New().String(), userID, action, entityType, entityID, details)
+}
+
+// recordAudit dipakai jika audit_id perlu diketahui lebih dulu, misalnya
+// untuk dirujuk oleh transaksi yang dibuat bersama audit tersebut
+func recordAudit(db *gorm.DB, auditID, userID, action, entityType, entityID string, details interface{}) error {
+	payload, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return db.Create(&models.AuditLog{
+		AuditID:    auditID,
+		UserID:     userID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    string(payload),
+	}).Error
+}
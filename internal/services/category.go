@@ -0,0 +1,122 @@
+package services
+
+import (
+	"inventory_app_backend/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TypeTree memuat seluruh jenis barang untuk menelusuri hierarki kategori.
+// Jumlah jenis barang kecil sehingga cukup dimuat sekali per request.
+type TypeTree struct {
+	types    map[string]models.ItemType
+	children map[string][]string
+	roots    []string
+}
+
+// LoadTypeTree memuat semua jenis barang, diurutkan berdasarkan nama
+func LoadTypeTree(db *gorm.DB) (*TypeTree, error) {
+	var types []models.ItemType
+	if err := db.Order("type_name ASC").Find(&types).Error; err != nil {
+		return nil, err
+	}
+
+	tree := &TypeTree{
+		types:    make(map[string]models.ItemType, len(types)),
+		children: make(map[string][]string),
+	}
+	for _, itemType := range types {
+		tree.types[itemType.TypeID] = itemType
+	}
+	for _, itemType := range types {
+		if itemType.ParentID != nil {
+			if _, ok := tree.types[*itemType.ParentID]; ok {
+				tree.children[*itemType.ParentID] = append(tree.children[*itemType.ParentID], itemType.TypeID)
+				continue
+			}
+		}
+		tree.roots = append(tree.roots, itemType.TypeID)
+	}
+	return tree, nil
+}
+
+// Get mengembalikan jenis barang berdasarkan ID
+func (t *TypeTree) Get(typeID string) (models.ItemType, bool) {
+	itemType, ok := t.types[typeID]
+	return itemType, ok
+}
+
+// Roots mengembalikan ID jenis barang tanpa induk
+func (t *TypeTree) Roots() []string {
+	return t.roots
+}
+
+// Children mengembalikan ID sub-jenis langsung dari typeID
+func (t *TypeTree) Children(typeID string) []string {
+	return t.children[typeID]
+}
+
+// Descendants mengembalikan typeID beserta seluruh turunannya
+func (t *TypeTree) Descendants(typeID string) []string {
+	ids := []string{typeID}
+	visited := map[string]bool{typeID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			if !visited[child] {
+				visited[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// IsDescendant mengecek apakah typeID adalah ancestorID atau turunannya
+func (t *TypeTree) IsDescendant(ancestorID, typeID string) bool {
+	for _, id := range t.Descendants(ancestorID) {
+		if id == typeID {
+			return true
+		}
+	}
+	return false
+}
+
+// Path mengembalikan jalur dari akar sampai typeID
+func (t *TypeTree) Path(typeID string) []models.ItemType {
+	var path []models.ItemType
+	visited := map[string]bool{}
+	for id := typeID; id != "" && !visited[id]; {
+		visited[id] = true
+		itemType, ok := t.types[id]
+		if !ok {
+			break
+		}
+		path = append([]models.ItemType{itemType}, path...)
+		id = ""
+		if itemType.ParentID != nil {
+			id = *itemType.ParentID
+		}
+	}
+	return path
+}
+
+// PathName menggabungkan jalur kategori, contoh "Elektronik > Kabel > HDMI"
+func (t *TypeTree) PathName(typeID string) string {
+	path := t.Path(typeID)
+	names := make([]string, 0, len(path))
+	for _, itemType := range path {
+		names = append(names, itemType.TypeName)
+	}
+	return strings.Join(names, " > ")
+}
+
+// TypeWithDescendants mengembalikan typeID beserta seluruh sub-jenisnya,
+// dipakai untuk filter dan rekap per kategori
+func TypeWithDescendants(db *gorm.DB, typeID string) ([]string, error) {
+	tree, err := LoadTypeTree(db)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Descendants(typeID), nil
+}
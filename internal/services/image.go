@@ -0,0 +1,69 @@
+package services
+
+import (
+	"inventory_app_backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AddItemImage menambahkan gambar ke akhir galeri barang. Gambar pertama
+// atau gambar yang diminta sebagai utama menggantikan gambar utama lama.
+func AddItemImage(tx *gorm.DB, image *models.ItemImage) error {
+	var maxOrder *int
+	if err := tx.Model(&models.ItemImage{}).
+		Where("item_id = ?", image.ItemID).
+		Select("MAX(sort_order)").
+		Scan(&maxOrder).Error; err != nil {
+		return err
+	}
+	image.SortOrder = 0
+	if maxOrder != nil {
+		image.SortOrder = *maxOrder + 1
+	}
+
+	if image.IsPrimary {
+		if err := tx.Model(&models.ItemImage{}).
+			Where("item_id = ?", image.ItemID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Create(image).Error; err != nil {
+		return err
+	}
+
+	return SyncPrimaryImage(tx, image.ItemID)
+}
+
+// SyncPrimaryImage menyalin gambar utama galeri ke kolom image dan
+// image_thumbnail barang agar daftar barang tidak perlu memuat galeri. Jika
+// belum ada gambar utama, gambar dengan urutan pertama dijadikan utama.
+func SyncPrimaryImage(tx *gorm.DB, itemID string) error {
+	var images []models.ItemImage
+	if err := tx.Where("item_id = ?", itemID).
+		Order("is_primary DESC, sort_order ASC, created_at ASC").
+		Limit(1).
+		Find(&images).Error; err != nil {
+		return err
+	}
+
+	var url, thumbnail string
+	if len(images) > 0 {
+		primary := images[0]
+		if !primary.IsPrimary {
+			if err := tx.Model(&models.ItemImage{}).
+				Where("image_id = ?", primary.ImageID).
+				Update("is_primary", true).Error; err != nil {
+				return err
+			}
+		}
+		url, thumbnail = primary.URL, primary.ThumbnailURL
+	}
+
+	return tx.Model(&models.Item{}).
+		Where("item_id = ?", itemID).
+		Updates(map[string]interface{}{
+			"image":           url,
+			"image_thumbnail": thumbnail,
+		}).Error
+}
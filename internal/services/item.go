@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"inventory_app_backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FindItemByCode mencari barang berdasarkan barcode, SKU, lalu ID barang
+// (label barang tanpa SKU mencetak ID). Mengembalikan nil jika tidak ada
+// barang dengan kode tersebut.
+func FindItemByCode(db *gorm.DB, code string) (*models.Item, error) {
+	var barcode models.ItemBarcode
+	err := db.Where("code = ?", code).First(&barcode).Error
+	if err == nil {
+		var item models.Item
+		if err := db.Where("item_id = ?", barcode.ItemID).First(&item).Error; err != nil {
+			return nil, err
+		}
+		return &item, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var item models.Item
+	err = db.Where("sku = ?", code).First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if _, parseErr := uuid.Parse(code); parseErr != nil {
+			return nil, nil
+		}
+		err = db.Where("item_id = ?", code).First(&item).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CodeUsage adalah barang yang sudah memakai sebuah kode sebagai SKU atau
+// barcode
+type CodeUsage struct {
+	ItemID  string
+	Barcode bool
+}
+
+// FindCodeUsage mengecek apakah kode sudah dipakai barang lain sebagai
+// barcode atau SKU. SKU dan barcode harus unik bersama karena FindItemByCode
+// mencari keduanya. Mengembalikan nil jika kode belum dipakai.
+func FindCodeUsage(db *gorm.DB, code, excludeItemID string) (*CodeUsage, error) {
+	var barcodes []models.ItemBarcode
+	if err := db.Where("code = ? AND item_id != ?", code, excludeItemID).
+		Limit(1).Find(&barcodes).Error; err != nil {
+		return nil, err
+	}
+	if len(barcodes) > 0 {
+		return &CodeUsage{ItemID: barcodes[0].ItemID, Barcode: true}, nil
+	}
+
+	var items []models.Item
+	if err := db.Where("sku = ? AND item_id != ?", code, excludeItemID).
+		Limit(1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		return &CodeUsage{ItemID: items[0].ItemID}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"inventory_app_backend/internal/config"
+	"inventory_app_backend/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultLoginMaxAttempts   = 5
+	defaultLoginIPMaxAttempts = 20
+
+	// loginFailureWindow adalah jeda tanpa kegagalan (setelah kunci berakhir)
+	// yang membuat hitungan kegagalan kembali ke nol
+	loginFailureWindow = 15 * time.Minute
+
+	// Lama kunci berlipat dua untuk setiap kegagalan setelah batas tercapai
+	loginLockBase = time.Minute
+	loginLockMax  = time.Hour
+)
+
+// LoginLockRemaining mengembalikan sisa waktu kunci login untuk username atau
+// IP. Nol berarti boleh mencoba login.
+func LoginLockRemaining(db *gorm.DB, username, ip string) (time.Duration, error) {
+	keys := []string{usernameThrottleKey(username)}
+	if ipThrottleEnabled() {
+		keys = append(keys, ipThrottleKey(ip))
+	}
+
+	var throttles []models.LoginThrottle
+	if err := db.Where("throttle_key IN ?", keys).
+		Find(&throttles).Error; err != nil {
+		return 0, err
+	}
+
+	var remaining time.Duration
+	now := time.Now()
+	for _, throttle := range throttles {
+		if throttle.LockedUntil != nil && throttle.LockedUntil.Sub(now) > remaining {
+			remaining = throttle.LockedUntil.Sub(now)
+		}
+	}
+	return remaining, nil
+}
+
+// RecordLoginFailure menambah hitungan kegagalan untuk username dan IP.
+// Username dicatat walaupun tidak terdaftar agar respons tidak membedakan
+// username yang ada dan tidak ada.
+func RecordLoginFailure(db *gorm.DB, username, ip string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := recordThrottleFailure(tx, usernameThrottleKey(username), loginMaxAttempts("LOGIN_MAX_ATTEMPTS", defaultLoginMaxAttempts)); err != nil {
+			return err
+		}
+		if !ipThrottleEnabled() {
+			return nil
+		}
+		return recordThrottleFailure(tx, ipThrottleKey(ip), loginMaxAttempts("LOGIN_IP_MAX_ATTEMPTS", defaultLoginIPMaxAttempts))
+	})
+}
+
+// ResetLoginFailures menghapus hitungan kegagalan username, dipakai setelah
+// login berhasil dan saat admin membuka kunci akun. Hitungan per IP tidak
+// direset agar satu akun valid tidak bisa dipakai menutupi tebakan massal.
+func ResetLoginFailures(db *gorm.DB, username string) error {
+	return db.Where("throttle_key = ?", usernameThrottleKey(username)).
+		Delete(&models.LoginThrottle{}).Error
+}
+
+func recordThrottleFailure(tx *gorm.DB, key string, maxAttempts int) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.LoginThrottle{ThrottleKey: key}).Error; err != nil {
+		return err
+	}
+
+	var throttle models.LoginThrottle
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&throttle, "throttle_key = ?", key).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if throttle.LastFailureAt != nil {
+		since := *throttle.LastFailureAt
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(since) {
+			since = *throttle.LockedUntil
+		}
+		if now.Sub(since) > loginFailureWindow {
+			throttle.Failures = 0
+		}
+	}
+
+	throttle.Failures++
+	throttle.LastFailureAt = &now
+	if throttle.Failures >= maxAttempts {
+		lockedUntil := now.Add(loginLockDuration(throttle.Failures - maxAttempts))
+		throttle.LockedUntil = &lockedUntil
+	}
+
+	return tx.Save(&throttle).Error
+}
+
+// loginLockDuration menghitung lama kunci: 1, 2, 4, 8 menit dan seterusnya
+// sampai batas loginLockMax
+func loginLockDuration(excess int) time.Duration {
+	lock := loginLockBase
+	for i := 0; i < excess && lock < loginLockMax; i++ {
+		lock *= 2
+	}
+	if lock > loginLockMax {
+		return loginLockMax
+	}
+	return lock
+}
+
+func usernameThrottleKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+// ipThrottleEnabled mengecek apakah kunci per IP dipakai. Tanpa
+// TRUSTED_PROXIES semua request di belakang reverse proxy tampak berasal dari
+// IP proxy, sehingga kunci per IP akan mengunci login semua user. Deployment
+// di belakang proxy wajib mengatur TRUSTED_PROXIES agar kunci per IP aktif.
+func ipThrottleEnabled() bool {
+	return config.Get("TRUSTED_PROXIES") != ""
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+func loginMaxAttempts(key string, fallback int) int {
+	attempts, err := strconv.Atoi(config.Get(key))
+	if err != nil || attempts < 1 {
+		return fallback
+	}
+	return attempts
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLoginLockRemainingIPKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies string
+		args           []driver.Value
+	}{
+		{"tanpa trusted proxy hanya username", "", []driver.Value{"user:budi"}},
+		{"dengan trusted proxy username dan IP", "10.0.0.1", []driver.Value{"user:budi", "ip:192.168.1.5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRUSTED_PROXIES", tt.trustedProxies)
+			db, mock := newMockDB(t)
+
+			lockedUntil := time.Now().Add(10 * time.Minute)
+			mock.ExpectQuery("SELECT \\* FROM `login_throttles` WHERE throttle_key IN").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"throttle_key", "failures", "locked_until"}).
+					AddRow("user:budi", 5, lockedUntil))
+
+			remaining, err := LoginLockRemaining(db, " Budi ", "192.168.1.5")
+			if err != nil {
+				t.Fatalf("LoginLockRemaining() error = %v", err)
+			}
+			if remaining <= 9*time.Minute {
+				t.Errorf("LoginLockRemaining() = %v, want sekitar 10 menit", remaining)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestLoginLockDuration(t *testing.T) {
+	tests := []struct {
+		excess int
+		want   time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{3, 8 * time.Minute},
+		{10, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := loginLockDuration(tt.excess); got != tt.want {
+			t.Errorf("loginLockDuration(%d) = %v, want %v", tt.excess, got, tt.want)
+		}
+	}
+}
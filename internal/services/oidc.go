@@ -0,0 +1,232 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"inventory_app_backend/internal/config"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/pkg/oidc"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// oidcStateTTL adalah batas waktu user menyelesaikan login di identity
+// provider
+const oidcStateTTL = 10 * time.Minute
+
+var (
+	ErrOIDCStateInvalid   = errors.New("oidc_state_invalid")
+	ErrOIDCNoRole         = errors.New("oidc_no_role")
+	ErrOIDCRoleInvalid    = errors.New("oidc_role_invalid")
+	ErrOIDCUsernameTaken  = errors.New("oidc_username_taken")
+	ErrOIDCUsernameAbsent = errors.New("oidc_username_absent")
+)
+
+// StartOIDCLogin membuat state, nonce dan PKCE verifier untuk satu percobaan
+// login. Verifier tidak pernah dikirim ke browser.
+func StartOIDCLogin(db *gorm.DB) (models.OIDCLoginState, error) {
+	state, err := randomToken()
+	if err != nil {
+		return models.OIDCLoginState{}, err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return models.OIDCLoginState{}, err
+	}
+
+	loginState := models.OIDCLoginState{
+		State:        state,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Bersihkan state yang tidak pernah diselesaikan
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&models.OIDCLoginState{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&loginState).Error
+	})
+	return loginState, err
+}
+
+// ConsumeOIDCState mengambil lalu menghapus state sehingga satu callback
+// tidak bisa diputar ulang
+func ConsumeOIDCState(db *gorm.DB, state string) (models.OIDCLoginState, error) {
+	var loginState models.OIDCLoginState
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&loginState, "state = ?", state).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOIDCStateInvalid
+			}
+			return err
+		}
+		return tx.Delete(&loginState).Error
+	})
+	if err != nil {
+		return models.OIDCLoginState{}, err
+	}
+	if time.Now().After(loginState.ExpiresAt) {
+		return models.OIDCLoginState{}, ErrOIDCStateInvalid
+	}
+	return loginState, nil
+}
+
+// OIDCRoleFor menentukan role dari claim grup. OIDC_ROLE_MAPPING berisi
+// pasangan grup=role dipisah koma, pasangan pertama yang cocok dipakai
+// sehingga grup dengan hak lebih tinggi sebaiknya ditulis lebih dulu. Jika
+// tidak ada yang cocok dipakai OIDC_DEFAULT_ROLE.
+func OIDCRoleFor(claims oidc.Claims) (string, error) {
+	groupsClaim := config.Get("OIDC_GROUPS_CLAIM")
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	groups := make(map[string]bool)
+	for _, group := range claims.Strings(groupsClaim) {
+		groups[group] = true
+	}
+
+	for _, pair := range strings.Split(config.Get("OIDC_ROLE_MAPPING"), ",") {
+		group, role, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if groups[strings.TrimSpace(group)] {
+			return strings.TrimSpace(role), nil
+		}
+	}
+
+	if role := config.Get("OIDC_DEFAULT_ROLE"); role != "" {
+		return role, nil
+	}
+	return "", ErrOIDCNoRole
+}
+
+// ProvisionOIDCUser mencari user berdasarkan subject dari identity provider
+// atau membuatnya saat login pertama. Role dan nama lengkap disinkronkan di
+// setiap login karena identity provider adalah sumber datanya.
+func ProvisionOIDCUser(db *gorm.DB, claims oidc.Claims, role string) (models.User, error) {
+	if role == constants.RoleServiceAccount {
+		return models.User{}, ErrOIDCRoleInvalid
+	}
+	var roleCount int64
+	if err := db.Model(&models.Role{}).Where("name = ?", role).Count(&roleCount).Error; err != nil {
+		return models.User{}, err
+	}
+	if roleCount == 0 {
+		return models.User{}, ErrOIDCRoleInvalid
+	}
+
+	subject := claims.String("sub")
+	fullName := claims.String("name")
+
+	var user models.User
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("auth_provider = ? AND external_subject = ?", constants.AuthProviderOIDC, subject).
+			First(&user).Error
+		if err == nil {
+			return syncOIDCUser(tx, &user, role, fullName)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		username := oidcUsername(claims)
+		if username == "" {
+			return ErrOIDCUsernameAbsent
+		}
+
+		// User lokal dengan username yang sama tidak dihubungkan otomatis
+		// agar akun tidak bisa diambil alih lewat identity provider
+		var existing int64
+		if err := tx.Model(&models.User{}).Where("username = ?", username).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrOIDCUsernameTaken
+		}
+
+		if fullName == "" {
+			fullName = username
+		}
+		user = models.User{
+			UserID:          uuid.New().String(),
+			Username:        username,
+			FullName:        fullName,
+			Role:            role,
+			IsActive:        true,
+			AuthProvider:    constants.AuthProviderOIDC,
+			ExternalSubject: &subject,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		return RecordAudit(tx, user.UserID, constants.AuditActionUserSSOProvision,
+			constants.AuditEntityUser, user.UserID, map[string]interface{}{
+				"username": user.Username,
+				"role":     user.Role,
+				"subject":  subject,
+			})
+	})
+	return user, err
+}
+
+func syncOIDCUser(tx *gorm.DB, user *models.User, role, fullName string) error {
+	updates := map[string]interface{}{}
+	if fullName != "" && fullName != user.FullName {
+		updates["full_name"] = fullName
+		user.FullName = fullName
+	}
+	previousRole := user.Role
+	if role != user.Role {
+		updates["role"] = role
+		user.Role = role
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(user).Updates(updates).Error; err != nil {
+		return err
+	}
+	if previousRole == role {
+		return nil
+	}
+	return RecordAudit(tx, user.UserID, constants.AuditActionUserSSORoleSync,
+		constants.AuditEntityUser, user.UserID, map[string]interface{}{
+			"username": user.Username,
+			"before":   previousRole,
+			"after":    role,
+		})
+}
+
+// oidcUsername mengambil username dari claim OIDC_USERNAME_CLAIM (default
+// preferred_username), lalu email
+func oidcUsername(claims oidc.Claims) string {
+	claim := config.Get("OIDC_USERNAME_CLAIM")
+	if claim == "" {
+		claim = "preferred_username"
+	}
+	if username := strings.TrimSpace(claims.String(claim)); username != "" {
+		return username
+	}
+	return strings.TrimSpace(claims.String("email"))
+}
+
+func randomToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
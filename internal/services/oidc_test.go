@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/pkg/oidc"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestOIDCRoleFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		mapping     string
+		defaultRole string
+		groupsClaim string
+		claims      oidc.Claims
+		want        string
+		wantErr     error
+	}{
+		{
+			name:    "pasangan pertama yang cocok dipakai",
+			mapping: "it-admin=admin, gudang=warehouse_admin",
+			claims:  oidc.Claims{"groups": []interface{}{"gudang", "it-admin"}},
+			want:    constants.RoleAdmin,
+		},
+		{
+			name:    "grup berupa string tunggal",
+			mapping: "it-admin=admin,gudang=warehouse_admin",
+			claims:  oidc.Claims{"groups": "gudang"},
+			want:    constants.RoleWarehouseAdmin,
+		},
+		{
+			name:        "claim grup dari konfigurasi",
+			mapping:     "manajer=warehouse_manager",
+			groupsClaim: "roles",
+			claims:      oidc.Claims{"groups": []interface{}{"lain"}, "roles": []interface{}{"manajer"}},
+			want:        constants.RoleWarehouseManager,
+		},
+		{
+			name:        "tidak ada grup yang cocok memakai role default",
+			mapping:     "it-admin=admin",
+			defaultRole: constants.RoleWarehouseManager,
+			claims:      oidc.Claims{"groups": []interface{}{"keuangan"}},
+			want:        constants.RoleWarehouseManager,
+		},
+		{
+			name:    "tanpa grup dan tanpa role default",
+			mapping: "it-admin=admin,rusak",
+			claims:  oidc.Claims{},
+			wantErr: ErrOIDCNoRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OIDC_ROLE_MAPPING", tt.mapping)
+			t.Setenv("OIDC_DEFAULT_ROLE", tt.defaultRole)
+			t.Setenv("OIDC_GROUPS_CLAIM", tt.groupsClaim)
+
+			got, err := OIDCRoleFor(tt.claims)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OIDCRoleFor() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("OIDCRoleFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func expectOIDCRole(mock sqlmock.Sqlmock, count int) {
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `roles` WHERE name = ").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func TestProvisionOIDCUserCreatesUser(t *testing.T) {
+	t.Setenv("OIDC_USERNAME_CLAIM", "")
+	db, mock := newMockDB(t)
+
+	expectOIDCRole(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE auth_provider = .* FOR UPDATE").
+		WithArgs(constants.AuthProviderOIDC, "subject-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users` WHERE username = ").
+		WithArgs("budi@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO `users`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `audit_logs`").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), constants.AuditActionUserSSOProvision,
+			constants.AuditEntityUser, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Tanpa preferred_username, email dipakai sebagai username
+	user, err := ProvisionOIDCUser(db, oidc.Claims{"sub": "subject-1", "email": "budi@example.com"},
+		constants.RoleWarehouseAdmin)
+	if err != nil {
+		t.Fatalf("ProvisionOIDCUser() error = %v", err)
+	}
+	if user.Username != "budi@example.com" || user.FullName != "budi@example.com" ||
+		user.Role != constants.RoleWarehouseAdmin || user.AuthProvider != constants.AuthProviderOIDC ||
+		user.ExternalSubject == nil || *user.ExternalSubject != "subject-1" {
+		t.Errorf("user = %+v", user)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProvisionOIDCUserUsernameTaken(t *testing.T) {
+	t.Setenv("OIDC_USERNAME_CLAIM", "")
+	db, mock := newMockDB(t)
+
+	expectOIDCRole(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE auth_provider = .* FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users` WHERE username = ").
+		WithArgs("budi").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := ProvisionOIDCUser(db, oidc.Claims{"sub": "subject-1", "preferred_username": "budi"},
+		constants.RoleWarehouseAdmin)
+	if !errors.Is(err, ErrOIDCUsernameTaken) {
+		t.Fatalf("ProvisionOIDCUser() error = %v, want %v", err, ErrOIDCUsernameTaken)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProvisionOIDCUserSyncsRole(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	expectOIDCRole(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE auth_provider = .* FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "full_name", "role"}).
+			AddRow("user-1", "budi", "Budi", constants.RoleWarehouseManager))
+	mock.ExpectExec("UPDATE `users` SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `audit_logs`").
+		WithArgs(sqlmock.AnyArg(), "user-1", constants.AuditActionUserSSORoleSync,
+			constants.AuditEntityUser, "user-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	user, err := ProvisionOIDCUser(db, oidc.Claims{"sub": "subject-1", "name": "Budi"}, constants.RoleAdmin)
+	if err != nil {
+		t.Fatalf("ProvisionOIDCUser() error = %v", err)
+	}
+	if user.Role != constants.RoleAdmin {
+		t.Errorf("Role = %q, want %q", user.Role, constants.RoleAdmin)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProvisionOIDCUserRejectsRole(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// Service account tidak boleh didapat lewat SSO, tanpa query ke database
+	if _, err := ProvisionOIDCUser(db, oidc.Claims{"sub": "subject-1"}, constants.RoleServiceAccount); !errors.Is(err, ErrOIDCRoleInvalid) {
+		t.Errorf("ProvisionOIDCUser(service_account) error = %v, want %v", err, ErrOIDCRoleInvalid)
+	}
+
+	expectOIDCRole(mock, 0)
+	if _, err := ProvisionOIDCUser(db, oidc.Claims{"sub": "subject-1"}, "tidak_ada"); !errors.Is(err, ErrOIDCRoleInvalid) {
+		t.Errorf("ProvisionOIDCUser(role tidak terdaftar) error = %v, want %v", err, ErrOIDCRoleInvalid)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
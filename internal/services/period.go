@@ -0,0 +1,28 @@
+package services
+
+import (
+	"errors"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FindClosedPeriod mengembalikan periode tertutup yang mencakup tanggal,
+// atau nil jika tanggal masih berada di periode terbuka
+func FindClosedPeriod(db *gorm.DB, date time.Time) (*models.ClosedPeriod, error) {
+	var period models.ClosedPeriod
+	err := db.Where("status = ? AND start_date <= ? AND end_date >= ?",
+		constants.PeriodStatusClosed,
+		date.Format("2006-01-02"),
+		date.Format("2006-01-02")).
+		First(&period).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
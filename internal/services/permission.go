@@ -0,0 +1,110 @@
+package services
+
+import (
+	"inventory_app_backend/internal/models"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// permissionCacheTTL membatasi berapa lama perubahan role dari instance lain
+// belum terlihat. Perubahan dari instance ini langsung menghapus cache.
+const permissionCacheTTL = time.Minute
+
+var (
+	permissionMu       sync.Mutex
+	permissionCache    map[string]map[string]bool
+	twoFactorRoleCache map[string]bool
+	permissionLoaded   time.Time
+)
+
+// RolePermissions mengembalikan izin efektif sebuah role. Seluruh isi
+// role_permissions di-cache agar middleware tidak query di setiap request.
+func RolePermissions(db *gorm.DB, role string) (map[string]bool, error) {
+	permissionMu.Lock()
+	defer permissionMu.Unlock()
+
+	if err := loadRoleCache(db); err != nil {
+		return nil, err
+	}
+	return permissionCache[role], nil
+}
+
+// RoleRequiresTwoFactor mengecek kebijakan wajib 2FA sebuah role
+func RoleRequiresTwoFactor(db *gorm.DB, role string) (bool, error) {
+	permissionMu.Lock()
+	defer permissionMu.Unlock()
+
+	if err := loadRoleCache(db); err != nil {
+		return false, err
+	}
+	return twoFactorRoleCache[role], nil
+}
+
+// loadRoleCache memuat ulang izin dan kebijakan role jika cache kosong atau
+// kedaluwarsa. Pemanggil harus memegang permissionMu.
+func loadRoleCache(db *gorm.DB) error {
+	if permissionCache != nil && time.Since(permissionLoaded) <= permissionCacheTTL {
+		return nil
+	}
+
+	var rows []models.RolePermission
+	if err := db.Find(&rows).Error; err != nil {
+		return err
+	}
+	var twoFactorRoles []string
+	if err := db.Model(&models.Role{}).Where("require_two_factor = ?", true).
+		Pluck("name", &twoFactorRoles).Error; err != nil {
+		return err
+	}
+
+	cache := make(map[string]map[string]bool)
+	for _, row := range rows {
+		if cache[row.RoleName] == nil {
+			cache[row.RoleName] = make(map[string]bool)
+		}
+		cache[row.RoleName][row.Permission] = true
+	}
+	twoFactorCache := make(map[string]bool, len(twoFactorRoles))
+	for _, name := range twoFactorRoles {
+		twoFactorCache[name] = true
+	}
+
+	permissionCache = cache
+	twoFactorRoleCache = twoFactorCache
+	permissionLoaded = time.Now()
+	return nil
+}
+
+// HasPermission mengecek apakah role memiliki izin tertentu
+func HasPermission(db *gorm.DB, role, permission string) (bool, error) {
+	permissions, err := RolePermissions(db, role)
+	if err != nil {
+		return false, err
+	}
+	return permissions[permission], nil
+}
+
+// PermissionNames mengembalikan izin role sebagai daftar terurut
+func PermissionNames(db *gorm.DB, role string) ([]string, error) {
+	permissions, err := RolePermissions(db, role)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(permissions))
+	for name := range permissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// InvalidatePermissionCache dipanggil setelah izin atau kebijakan role diubah
+func InvalidatePermissionCache() {
+	permissionMu.Lock()
+	permissionCache = nil
+	permissionMu.Unlock()
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StockDrift adalah selisih antara stok tercatat di tabel items dengan stok
+// yang dihitung ulang dari riwayat transaksi
+type StockDrift struct {
+	ItemID             string
+	ItemName           string
+	RecordedStock      int
+	ExpectedStock      int
+	RecordedQuarantine int
+	ExpectedQuarantine int
+	Fixed              bool
+	Skipped            string
+	// AdjustmentID adalah transaksi penyesuaian yang dibuat saat koreksi
+	AdjustmentID string
+}
+
+type ReconciliationResult struct {
+	CheckedItems int
+	Drifts       []StockDrift
+}
+
+// ExpectedStock adalah stok yang dihitung ulang dari riwayat transaksi
+type ExpectedStock struct {
+	ItemID             string
+	ExpectedStock      int
+	ExpectedQuarantine int
+}
+
+// expectedStockQuery menghitung stok tersedia dan stok karantina per item dari
+// riwayat transaksi dengan aturan yang sama seperti trigger stok. Penyesuaian
+// rekonsiliasi tidak dihitung karena hanya mengoreksi stok tercatat.
+const expectedStockQuery = `
+SELECT t.item_id,
+	COALESCE(SUM(CASE
+		WHEN t.transaction_type = 'out' THEN -t.quantity
+		WHEN t.inspection_status = 'pending' THEN 0
+		WHEN t.inspection_status = 'inspected' THEN COALESCE(r.released, 0)
+		ELSE t.quantity
+	END), 0) AS expected_stock,
+	COALESCE(SUM(CASE
+		WHEN t.transaction_type = 'in' AND t.inspection_status = 'pending' THEN t.quantity
+		ELSE 0
+	END), 0) AS expected_quarantine
+FROM transactions t
+LEFT JOIN (
+	SELECT transaction_id, SUM(released_quantity) AS released
+	FROM inspections
+	GROUP BY transaction_id
+) r ON r.transaction_id = t.transaction_id
+WHERE COALESCE(t.reference_type, '') <> '` + constants.ReferenceTypeStockReconciliation + `'
+GROUP BY t.item_id`
+
+// ReconcileStock menghitung ulang stok setiap item dari riwayat transaksi dan
+// melaporkan selisihnya. Jika fix bernilai true, setiap selisih dikoreksi
+// dengan transaksi penyesuaian yang dirujuk audit log atas nama userID.
+func ReconcileStock(db *gorm.DB, fix bool, userID string) (*ReconciliationResult, error) {
+	var items []models.Item
+	if err := db.Order("item_name ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []ExpectedStock
+	if err := db.Raw(expectedStockQuery).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	expected := make(map[string]ExpectedStock, len(rows))
+	for _, row := range rows {
+		expected[row.ItemID] = row
+	}
+
+	result := &ReconciliationResult{CheckedItems: len(items)}
+	for _, item := range items {
+		row := expected[item.ItemID]
+		if row.ExpectedStock == item.Stock && row.ExpectedQuarantine == item.QuarantineStock {
+			continue
+		}
+
+		drift := StockDrift{
+			ItemID:             item.ItemID,
+			ItemName:           item.ItemName,
+			RecordedStock:      item.Stock,
+			ExpectedStock:      row.ExpectedStock,
+			RecordedQuarantine: item.QuarantineStock,
+			ExpectedQuarantine: row.ExpectedQuarantine,
+		}
+
+		if fix {
+			if err := fixDrift(db, &drift, userID); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Drifts = append(result.Drifts, drift)
+	}
+
+	return result, nil
+}
+
+// fixDrift membuat transaksi penyesuaian sebesar selisih stok sehingga trigger
+// membawa stok tercatat ke stok seharusnya. Stok tidak ditulis langsung agar
+// koreksi terlihat di daftar transaksi.
+func fixDrift(db *gorm.DB, drift *StockDrift, userID string) error {
+	// Riwayat yang menghasilkan stok negatif harus diperbaiki manual
+	if drift.ExpectedStock < 0 {
+		drift.Skipped = constants.MsgReconcileNegativeHistory
+		return nil
+	}
+	// Trigger tidak punya jalur untuk mengurangi stok karantina lewat
+	// transaksi baru
+	if drift.ExpectedQuarantine != drift.RecordedQuarantine {
+		drift.Skipped = constants.MsgReconcileQuarantineDrift
+		return nil
+	}
+
+	today := time.Now()
+	closed, err := FindClosedPeriod(db, today)
+	if err != nil {
+		return err
+	}
+	if closed != nil {
+		drift.Skipped = constants.MsgReconcilePeriodClosed
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Hanya koreksi jika stok belum berubah sejak dibaca
+		var item models.Item
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&item, "item_id = ?", drift.ItemID).Error; err != nil {
+			return err
+		}
+		if item.Stock != drift.RecordedStock || item.QuarantineStock != drift.RecordedQuarantine {
+			drift.Skipped = constants.MsgReconcileStockChanged
+			return nil
+		}
+
+		adjustment := drift.ExpectedStock - drift.RecordedStock
+		transactionType := constants.TransactionTypeIn
+		if adjustment < 0 {
+			transactionType = constants.TransactionTypeOut
+			adjustment = -adjustment
+		}
+
+		auditID := uuid.New().String()
+		referenceType := constants.ReferenceTypeStockReconciliation
+		transaction := models.Transaction{
+			TransactionID:    uuid.New().String(),
+			ItemID:           drift.ItemID,
+			Date:             today,
+			Quantity:         adjustment,
+			TransactionType:  transactionType,
+			InspectionStatus: constants.InspectionStatusNone,
+			Description:      constants.MsgReconcileAdjustment,
+			ReferenceType:    &referenceType,
+			ReferenceID:      &auditID,
+			UserID:           userID,
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+
+		if err := recordAudit(tx, auditID, userID, constants.AuditActionStockReconcile,
+			constants.AuditEntityItem, drift.ItemID, map[string]interface{}{
+				"item_name":           drift.ItemName,
+				"recorded_stock":      drift.RecordedStock,
+				"expected_stock":      drift.ExpectedStock,
+				"stock_adjustment":    drift.ExpectedStock - drift.RecordedStock,
+				"recorded_quarantine": drift.RecordedQuarantine,
+				"expected_quarantine": drift.ExpectedQuarantine,
+				"transaction_id":      transaction.TransactionID,
+			}); err != nil {
+			return err
+		}
+
+		drift.Fixed = true
+		drift.AdjustmentID = transaction.TransactionID
+		return nil
+	})
+}
+
+// ExpectedStockFor menghitung stok dari riwayat transaksi untuk item tertentu.
+// Item tanpa transaksi tidak muncul di hasil (stoknya 0).
+func ExpectedStockFor(db *gorm.DB, itemIDs []string) (map[string]ExpectedStock, error) {
+	if len(itemIDs) == 0 {
+		return map[string]ExpectedStock{}, nil
+	}
+
+	var rows []ExpectedStock
+	if err := db.Raw("SELECT * FROM ("+expectedStockQuery+") e WHERE e.item_id IN ?", itemIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	expected := make(map[string]ExpectedStock, len(rows))
+	for _, row := range rows {
+		expected[row.ItemID] = row
+	}
+	return expected, nil
+}
+
+// RecomputeStock menimpa stok tercatat dengan stok dari riwayat transaksi.
+// Dipakai setelah perubahan massal yang tidak bisa diandalkan pada trigger.
+func RecomputeStock(db *gorm.DB, itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	expected, err := ExpectedStockFor(db, itemIDs)
+	if err != nil {
+		return err
+	}
+	for _, itemID := range itemIDs {
+		row := expected[itemID]
+		if err := db.Model(&models.Item{}).
+			Where("item_id = ?", itemID).
+			Updates(map[string]interface{}{
+				"stock":            row.ExpectedStock,
+				"quarantine_stock": row.ExpectedQuarantine,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
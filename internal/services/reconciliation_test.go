@@ -0,0 +1,19 @@
+package services
+
+import "testing"
+
+func TestReconciliationHelpersSkipEmptyItems(t *testing.T) {
+	// Tanpa item tidak boleh ada query IN kosong ke database
+	db, mock := newMockDB(t)
+
+	expected, err := ExpectedStockFor(db, nil)
+	if err != nil || len(expected) != 0 {
+		t.Fatalf("ExpectedStockFor(nil) = %v, %v", expected, err)
+	}
+	if err := RecomputeStock(db, []string{}); err != nil {
+		t.Fatalf("RecomputeStock() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockMovement adalah perubahan stok tersedia pada tanggal tertentu
+type StockMovement struct {
+	Date  time.Time
+	Delta int
+}
+
+// NegativeBalance menunjukkan tanggal pertama saat saldo stok menjadi negatif
+type NegativeBalance struct {
+	Date    time.Time
+	Balance int
+}
+
+type movementRow struct {
+	TransactionID    string
+	Date             time.Time
+	Quantity         int
+	TransactionType  string
+	InspectionStatus string
+	Released         int
+}
+
+// ItemMovements mengambil seluruh pergerakan stok tersedia dari riwayat transaksi.
+// Barang karantina tidak dihitung, barang yang sudah diinspeksi dihitung sebesar
+// jumlah yang diterima.
+func ItemMovements(db *gorm.DB, itemID string, excludeTransactionIDs []string) ([]StockMovement, error) {
+	query := db.Table("transactions AS t").
+		Select("t.transaction_id, t.date, t.quantity, t.transaction_type, t.inspection_status, "+
+			"COALESCE(SUM(i.released_quantity), 0) AS released").
+		Joins("LEFT JOIN inspections i ON i.transaction_id = t.transaction_id").
+		Where("t.item_id = ?", itemID).
+		// Penyesuaian rekonsiliasi hanya mengoreksi stok tercatat, bukan pergerakan barang
+		Where("COALESCE(t.reference_type, '') <> ?", constants.ReferenceTypeStockReconciliation).
+		Group("t.transaction_id, t.date, t.quantity, t.transaction_type, t.inspection_status")
+	if len(excludeTransactionIDs) > 0 {
+		query = query.Where("t.transaction_id NOT IN ?", excludeTransactionIDs)
+	}
+
+	var rows []movementRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	movements := make([]StockMovement, 0, len(rows))
+	for _, row := range rows {
+		movements = append(movements, StockMovement{
+			Date:  row.Date,
+			Delta: movementDelta(row),
+		})
+	}
+	return movements, nil
+}
+
+func movementDelta(row movementRow) int {
+	return MovementDelta(row.TransactionType, row.InspectionStatus, row.Quantity, row.Released)
+}
+
+// MovementDelta menghitung efek satu transaksi terhadap stok tersedia
+func MovementDelta(transactionType, inspectionStatus string, quantity, released int) int {
+	switch {
+	case transactionType == constants.TransactionTypeOut:
+		return -quantity
+	case inspectionStatus == constants.InspectionStatusPending:
+		return 0
+	case inspectionStatus == constants.InspectionStatusInspected:
+		return released
+	default:
+		return quantity
+	}
+}
+
+// ReleasedQuantity mengambil jumlah barang yang diterima dari hasil inspeksi transaksi
+func ReleasedQuantity(db *gorm.DB, transactionID string) (int, error) {
+	var released int
+	err := db.Model(&models.Inspection{}).
+		Where("transaction_id = ?", transactionID).
+		Select("COALESCE(SUM(released_quantity), 0)").
+		Scan(&released).Error
+	return released, err
+}
+
+// CheckStockBalance memastikan perubahan pergerakan stok tidak membuat saldo
+// negatif pada tanggal efektifnya maupun tanggal setelahnya. Transaksi di
+// excludeTransactionIDs dianggap dihapus dan extra dianggap ditambahkan.
+// Mengembalikan nil jika saldo aman atau kebijakan jenis barang mengizinkan.
+func CheckStockBalance(db *gorm.DB, item models.Item, excludeTransactionIDs []string, extra []StockMovement) (*NegativeBalance, error) {
+	var itemType models.ItemType
+	if err := db.Where("type_id = ?", item.TypeID).First(&itemType).Error; err != nil {
+		return nil, err
+	}
+	if itemType.StockPolicy == constants.StockPolicyCurrentOnly {
+		return nil, nil
+	}
+
+	before, err := ItemMovements(db, item.ItemID, nil)
+	if err != nil {
+		return nil, err
+	}
+	after, err := ItemMovements(db, item.ItemID, excludeTransactionIDs)
+	if err != nil {
+		return nil, err
+	}
+	after = append(after, extra...)
+
+	return FirstNegativeBalance(before, after), nil
+}
+
+// FirstNegativeBalance membandingkan saldo harian sebelum dan sesudah perubahan,
+// dan mengembalikan tanggal pertama saldo baru negatif dan lebih buruk dari
+// saldo lama. Saldo yang sudah negatif sebelumnya tidak dianggap pelanggaran.
+func FirstNegativeBalance(before, after []StockMovement) *NegativeBalance {
+	beforeDaily := dailyDeltas(before)
+	afterDaily := dailyDeltas(after)
+
+	dateSet := make(map[string]time.Time)
+	for key, m := range beforeDaily {
+		dateSet[key] = m.Date
+	}
+	for key, m := range afterDaily {
+		dateSet[key] = m.Date
+	}
+
+	keys := make([]string, 0, len(dateSet))
+	for key := range dateSet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	beforeBalance, afterBalance := 0, 0
+	for _, key := range keys {
+		beforeBalance += beforeDaily[key].Delta
+		afterBalance += afterDaily[key].Delta
+		if afterBalance < 0 && afterBalance < beforeBalance {
+			return &NegativeBalance{Date: dateSet[key], Balance: afterBalance}
+		}
+	}
+	return nil
+}
+
+func dailyDeltas(movements []StockMovement) map[string]StockMovement {
+	daily := make(map[string]StockMovement)
+	for _, m := range movements {
+		key := m.Date.Format("2006-01-02")
+		current := daily[key]
+		current.Date = m.Date
+		current.Delta += m.Delta
+		daily[key] = current
+	}
+	return daily
+}
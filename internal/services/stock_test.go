@@ -0,0 +1,106 @@
+package services
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"testing"
+	"time"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, time.January, d, 10, 0, 0, 0, time.UTC)
+}
+
+func TestFirstNegativeBalance(t *testing.T) {
+	history := []StockMovement{
+		{Date: day(1), Delta: 10},
+		{Date: day(5), Delta: -8},
+		{Date: day(10), Delta: 5},
+	}
+
+	tests := []struct {
+		name    string
+		after   []StockMovement
+		want    *NegativeBalance
+		wantNil bool
+	}{
+		{
+			name:    "tanpa perubahan",
+			after:   history,
+			wantNil: true,
+		},
+		{
+			name:    "barang keluar setelah saldo cukup",
+			after:   append(append([]StockMovement{}, history...), StockMovement{Date: day(12), Delta: -7}),
+			wantNil: true,
+		},
+		{
+			name:  "barang keluar mundur tanggal melewati saldo",
+			after: append(append([]StockMovement{}, history...), StockMovement{Date: day(3), Delta: -5}),
+			want:  &NegativeBalance{Date: day(5), Balance: -3},
+		},
+		{
+			name:  "barang keluar di hari yang sama digabung per hari",
+			after: append(append([]StockMovement{}, history...), StockMovement{Date: day(1).Add(time.Hour), Delta: -11}),
+			want:  &NegativeBalance{Date: day(1).Add(time.Hour), Balance: -1},
+		},
+		{
+			name:  "menghapus barang masuk",
+			after: history[1:],
+			want:  &NegativeBalance{Date: day(5), Balance: -8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FirstNegativeBalance(history, tt.after)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("FirstNegativeBalance() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("FirstNegativeBalance() = nil, want %+v", tt.want)
+			}
+			if got.Balance != tt.want.Balance || got.Date.Format("2006-01-02") != tt.want.Date.Format("2006-01-02") {
+				t.Fatalf("FirstNegativeBalance() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstNegativeBalanceKeepsExistingNegative(t *testing.T) {
+	// Saldo yang sudah negatif sebelum perubahan tidak dianggap disebabkan
+	// perubahan selama tidak bertambah buruk
+	before := []StockMovement{{Date: day(1), Delta: -2}, {Date: day(2), Delta: 5}}
+	after := append(append([]StockMovement{}, before...), StockMovement{Date: day(3), Delta: 1})
+
+	if got := FirstNegativeBalance(before, after); got != nil {
+		t.Fatalf("FirstNegativeBalance() = %+v, want nil", got)
+	}
+}
+
+func TestMovementDelta(t *testing.T) {
+	tests := []struct {
+		name             string
+		transactionType  string
+		inspectionStatus string
+		quantity         int
+		released         int
+		want             int
+	}{
+		{"keluar", constants.TransactionTypeOut, constants.InspectionStatusNone, 4, 0, -4},
+		{"masuk tanpa inspeksi", constants.TransactionTypeIn, constants.InspectionStatusNone, 4, 0, 4},
+		{"masuk menunggu inspeksi", constants.TransactionTypeIn, constants.InspectionStatusPending, 4, 0, 0},
+		{"masuk sudah diinspeksi", constants.TransactionTypeIn, constants.InspectionStatusInspected, 4, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MovementDelta(tt.transactionType, tt.inspectionStatus, tt.quantity, tt.released)
+			if got != tt.want {
+				t.Fatalf("MovementDelta() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"inventory_app_backend/internal/config"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/pkg/firebase"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultImageDeleteGrace adalah masa tenggang sebelum file gambar yang
+	// diganti atau dihapus benar-benar dihapus dari bucket
+	defaultImageDeleteGrace = 24 * time.Hour
+
+	// defaultAttachmentRetention adalah lama lampiran disimpan setelah
+	// transaksinya dihapus
+	defaultAttachmentRetention = 30 * 24 * time.Hour
+)
+
+// ScheduleImageDeletion menjadwalkan penghapusan file gambar setelah masa
+// tenggang IMAGE_DELETE_GRACE_HOURS. URL di luar bucket aplikasi diabaikan.
+func ScheduleImageDeletion(tx *gorm.DB, urls ...string) error {
+	return scheduleDeletion(tx, imageDeleteGrace(), urls...)
+}
+
+// ScheduleAttachmentDeletion menjadwalkan penghapusan file lampiran sesuai
+// retensi ATTACHMENT_RETENTION_DAYS
+func ScheduleAttachmentDeletion(tx *gorm.DB, attachments ...models.TransactionAttachment) error {
+	var urls []string
+	for _, attachment := range attachments {
+		urls = append(urls, attachment.URL)
+	}
+	return scheduleDeletion(tx, attachmentRetention(), urls...)
+}
+
+func scheduleDeletion(tx *gorm.DB, after time.Duration, urls ...string) error {
+	deleteAfter := time.Now().Add(after)
+
+	var rows []models.PendingDeletion
+	for _, url := range urls {
+		path := firebase.ObjectPath(url)
+		if path == "" {
+			continue
+		}
+		rows = append(rows, models.PendingDeletion{
+			DeletionID:  uuid.New().String(),
+			ObjectPath:  path,
+			DeleteAfter: deleteAfter,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// ScheduleItemImageDeletion menjadwalkan penghapusan semua varian gambar
+func ScheduleItemImageDeletion(tx *gorm.DB, images ...models.ItemImage) error {
+	var urls []string
+	for _, image := range images {
+		urls = append(urls, image.URL, image.MediumURL, image.ThumbnailURL)
+	}
+	return ScheduleImageDeletion(tx, urls...)
+}
+
+// ReferencedObjectPaths mengumpulkan path objek yang masih dipakai barang
+// atau lampiran transaksi, ditambah path yang masih menunggu jadwal
+// penghapusan agar masa tenggang dan retensi tidak dilewati
+func ReferencedObjectPaths(db *gorm.DB) (map[string]bool, error) {
+	var urls []string
+	if err := db.Model(&models.Item{}).
+		Where("image <> ''").
+		Pluck("image", &urls).Error; err != nil {
+		return nil, err
+	}
+
+	var thumbnails []string
+	if err := db.Model(&models.Item{}).
+		Where("image_thumbnail <> ''").
+		Pluck("image_thumbnail", &thumbnails).Error; err != nil {
+		return nil, err
+	}
+	urls = append(urls, thumbnails...)
+
+	var images []models.ItemImage
+	if err := db.Select("url", "medium_url", "thumbnail_url").Find(&images).Error; err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		urls = append(urls, image.URL, image.MediumURL, image.ThumbnailURL)
+	}
+
+	var attachments []string
+	if err := db.Model(&models.TransactionAttachment{}).Pluck("url", &attachments).Error; err != nil {
+		return nil, err
+	}
+	urls = append(urls, attachments...)
+
+	var pending []string
+	if err := db.Model(&models.PendingDeletion{}).
+		Where("delete_after > ?", time.Now()).
+		Pluck("object_path", &pending).Error; err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]bool, len(urls)+len(pending))
+	for _, url := range urls {
+		if path := firebase.ObjectPath(url); path != "" {
+			paths[path] = true
+		}
+	}
+	for _, path := range pending {
+		paths[path] = true
+	}
+	return paths, nil
+}
+
+// PurgePendingDeletions menghapus file yang masa tenggangnya sudah lewat.
+// File yang ternyata dipakai lagi (misalnya setelah penggabungan barang)
+// tidak dihapus. Mengembalikan jumlah file yang dihapus.
+func PurgePendingDeletions(db *gorm.DB) (int, error) {
+	var due []models.PendingDeletion
+	if err := db.Where("delete_after <= ?", time.Now()).
+		Order("delete_after ASC").
+		Find(&due).Error; err != nil {
+		return 0, err
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	referenced, err := ReferencedObjectPaths(db)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, row := range due {
+		if !referenced[row.ObjectPath] {
+			if err := firebase.DeleteObject(row.ObjectPath); err != nil {
+				// Dicoba lagi pada putaran berikutnya
+				log.Printf("Gagal menghapus file %s: %v", row.ObjectPath, err)
+				continue
+			}
+			deleted++
+		}
+		if err := db.Delete(&row).Error; err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// RunPendingDeletions menjalankan PurgePendingDeletions secara berkala
+func RunPendingDeletions(db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := PurgePendingDeletions(db); err != nil {
+			log.Printf("Gagal membersihkan file storage: %v", err)
+		} else if deleted > 0 {
+			log.Printf("%d file lama dihapus dari storage", deleted)
+		}
+		<-ticker.C
+	}
+}
+
+// FindOrphanedImages mencari objek dengan prefix tertentu yang tidak dipakai
+// barang atau lampiran mana pun dan tidak sedang menunggu jadwal penghapusan.
+// Objek yang lebih muda dari minAge dilewati karena upload dilakukan sebelum
+// data barang tersimpan.
+func FindOrphanedImages(db *gorm.DB, prefix string, minAge time.Duration) ([]firebase.ObjectInfo, error) {
+	referenced, err := ReferencedObjectPaths(db)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := firebase.ListObjects(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterOrphans(objects, referenced, time.Now().Add(-minAge)), nil
+}
+
+func filterOrphans(objects []firebase.ObjectInfo, referenced map[string]bool, cutoff time.Time) []firebase.ObjectInfo {
+	var orphans []firebase.ObjectInfo
+	for _, object := range objects {
+		if referenced[object.Path] || object.Created.After(cutoff) {
+			continue
+		}
+		orphans = append(orphans, object)
+	}
+	return orphans
+}
+
+func imageDeleteGrace() time.Duration {
+	hours, err := strconv.Atoi(config.Get("IMAGE_DELETE_GRACE_HOURS"))
+	if err != nil || hours < 0 {
+		return defaultImageDeleteGrace
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func attachmentRetention() time.Duration {
+	days, err := strconv.Atoi(config.Get("ATTACHMENT_RETENTION_DAYS"))
+	if err != nil || days < 0 {
+		return defaultAttachmentRetention
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
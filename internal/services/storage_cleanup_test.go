@@ -0,0 +1,115 @@
+package services
+
+import (
+	"database/sql/driver"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/pkg/firebase"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}),
+		&gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db, mock
+}
+
+func TestReferencedObjectPathsIncludesPendingDeletions(t *testing.T) {
+	t.Setenv("FIREBASE_BUCKET_NAME", "bucket")
+	const base = "https://storage.googleapis.com/bucket/"
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("SELECT `image` FROM `items`").
+		WillReturnRows(sqlmock.NewRows([]string{"image"}).AddRow(base + "items/a.jpg"))
+	mock.ExpectQuery("SELECT `image_thumbnail` FROM `items`").
+		WillReturnRows(sqlmock.NewRows([]string{"image_thumbnail"}).AddRow(base + "items/a_thumb.jpg"))
+	mock.ExpectQuery("FROM `item_images`").
+		WillReturnRows(sqlmock.NewRows([]string{"url", "medium_url", "thumbnail_url"}).
+			AddRow(base+"items/b.jpg", base+"items/b_medium.jpg", base+"items/b_thumb.jpg"))
+	mock.ExpectQuery("SELECT `url` FROM `transaction_attachments`").
+		WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("https://example.com/luar.pdf"))
+	mock.ExpectQuery("SELECT `object_path` FROM `pending_deletions` WHERE delete_after > ").
+		WillReturnRows(sqlmock.NewRows([]string{"object_path"}).AddRow("attachments/dihapus.pdf"))
+
+	paths, err := ReferencedObjectPaths(db)
+	if err != nil {
+		t.Fatalf("ReferencedObjectPaths() error = %v", err)
+	}
+
+	for _, path := range []string{
+		"items/a.jpg", "items/a_thumb.jpg", "items/b.jpg", "items/b_medium.jpg", "items/b_thumb.jpg",
+		"attachments/dihapus.pdf",
+	} {
+		if !paths[path] {
+			t.Errorf("path %s harus dianggap dipakai", path)
+		}
+	}
+	if len(paths) != 6 {
+		t.Errorf("paths = %v, URL di luar bucket tidak boleh ikut", paths)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFilterOrphans(t *testing.T) {
+	now := time.Now()
+	objects := []firebase.ObjectInfo{
+		{Path: "items/dipakai.jpg", Created: now.Add(-72 * time.Hour)},
+		{Path: "items/menunggu.jpg", Created: now.Add(-72 * time.Hour)},
+		{Path: "items/baru.jpg", Created: now.Add(-time.Hour)},
+		{Path: "items/yatim.jpg", Created: now.Add(-72 * time.Hour)},
+	}
+	referenced := map[string]bool{"items/dipakai.jpg": true, "items/menunggu.jpg": true}
+
+	orphans := filterOrphans(objects, referenced, now.Add(-24*time.Hour))
+	if len(orphans) != 1 || orphans[0].Path != "items/yatim.jpg" {
+		t.Fatalf("filterOrphans() = %+v, want hanya items/yatim.jpg", orphans)
+	}
+}
+
+// deleteAfterNear mencocokkan argumen delete_after dengan toleransi waktu
+type deleteAfterNear struct{ want time.Time }
+
+func (d deleteAfterNear) Match(v driver.Value) bool {
+	got, ok := v.(time.Time)
+	return ok && got.Sub(d.want).Abs() < time.Minute
+}
+
+func TestScheduleAttachmentDeletionUsesRetention(t *testing.T) {
+	t.Setenv("FIREBASE_BUCKET_NAME", "bucket")
+	t.Setenv("ATTACHMENT_RETENTION_DAYS", "7")
+	const base = "https://storage.googleapis.com/bucket/"
+
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `pending_deletions`").
+		WithArgs(sqlmock.AnyArg(), "attachments/nota.pdf", deleteAfterNear{time.Now().Add(7 * 24 * time.Hour)}, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := ScheduleAttachmentDeletion(db,
+		models.TransactionAttachment{URL: base + "attachments/nota.pdf"},
+		models.TransactionAttachment{URL: "https://example.com/luar.pdf"})
+	if err != nil {
+		t.Fatalf("ScheduleAttachmentDeletion() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"inventory_app_backend/internal/config"
+	constants "inventory_app_backend/internal/constant"
+	"inventory_app_backend/internal/models"
+	"inventory_app_backend/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPIssuer mengembalikan nama aplikasi untuk authenticator
+func TOTPIssuer() string {
+	if issuer := config.Get("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return constants.DefaultTOTPIssuer
+}
+
+// GenerateRecoveryCodes mengganti semua kode pemulihan user dengan kode baru.
+// Kode asli hanya dikembalikan sekali, yang disimpan hanya hash-nya.
+func GenerateRecoveryCodes(tx *gorm.DB, userID string) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, constants.RecoveryCodeCount)
+	rows := make([]models.UserRecoveryCode, 0, constants.RecoveryCodeCount)
+	for i := 0; i < constants.RecoveryCodeCount; i++ {
+		secret := make([]byte, 5)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		// 8 karakter base32 dipecah dua agar mudah dicatat
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(secret))
+		code := encoded[:4] + "-" + encoded[4:]
+
+		codes = append(codes, code)
+		rows = append(rows, models.UserRecoveryCode{
+			CodeID:   uuid.New().String(),
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		})
+	}
+
+	if err := tx.Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifySecondFactor menerima kode TOTP dari authenticator atau kode
+// pemulihan. Kode TOTP yang sudah pernah dipakai dan kode pemulihan yang
+// sudah terpakai ditolak.
+func VerifySecondFactor(db *gorm.DB, user models.User, code string) (bool, error) {
+	if user.TOTPSecret == nil {
+		return false, nil
+	}
+
+	if step, ok := utils.ValidateTOTP(*user.TOTPSecret, code, time.Now()); ok {
+		// Update bersyarat sehingga kode yang sama tidak bisa dipakai dua
+		// kali walaupun dua request datang bersamaan
+		result := db.Model(&models.User{}).
+			Where("user_id = ? AND totp_last_step < ?", user.UserID, step).
+			Update("totp_last_step", step)
+		return result.RowsAffected == 1, result.Error
+	}
+
+	result := db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.UserID, hashRecoveryCode(code)).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// RemainingRecoveryCodes menghitung kode pemulihan yang belum dipakai
+func RemainingRecoveryCodes(db *gorm.DB, userID string) (int64, error) {
+	var count int64
+	err := db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// hashRecoveryCode menormalkan kode (huruf kecil, tanpa strip dan spasi)
+// sebelum dihitung hash-nya
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	constants "inventory_app_backend/internal/constant"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// AttachmentMaxUploadSize adalah batas ukuran satu file lampiran
+const AttachmentMaxUploadSize = 10 << 20 // 10MB
+
+// NormalizedAttachment adalah lampiran yang sudah divalidasi dari isinya dan
+// siap diupload. Gambar sudah dinormalisasi seperti gambar barang.
+type NormalizedAttachment struct {
+	Data        []byte
+	ContentType string
+	FileName    string
+	Ext         string
+}
+
+// NormalizeAttachment memvalidasi lampiran berdasarkan isi file. Hanya PDF,
+// JPEG dan PNG yang diterima. Gambar di-encode ulang agar metadata EXIF dan
+// GPS terbuang, PDF disimpan apa adanya.
+func NormalizeAttachment(file *multipart.FileHeader) (*NormalizedAttachment, map[string]string, error) {
+	if file.Size == 0 {
+		return nil, attachmentRejection(constants.MsgImageEmpty, constants.ErrCodeAttachmentEmpty), errors.New("empty_file")
+	}
+	if file.Size > AttachmentMaxUploadSize {
+		return nil, attachmentRejection(constants.MsgAttachmentAllowedSize, constants.ErrCodeAttachmentTooLarge), errors.New("too_large")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("upload_failed: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, AttachmentMaxUploadSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("upload_failed: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, attachmentRejection(constants.MsgImageEmpty, constants.ErrCodeAttachmentEmpty), errors.New("empty_file")
+	}
+	if len(data) > AttachmentMaxUploadSize {
+		return nil, attachmentRejection(constants.MsgAttachmentAllowedSize, constants.ErrCodeAttachmentTooLarge), errors.New("too_large")
+	}
+
+	// Jenis file ditentukan dari magic bytes
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "application/pdf":
+		if !bytes.HasPrefix(data, []byte("%PDF-")) {
+			return nil, attachmentRejection(constants.MsgAttachmentTypeInvalid, constants.ErrCodeAttachmentUnsupportedType), errors.New("invalid_format")
+		}
+		return &NormalizedAttachment{
+			Data:        data,
+			ContentType: contentType,
+			FileName:    attachmentFileName(file.Filename, ".pdf"),
+			Ext:         ".pdf",
+		}, nil, nil
+	case "image/jpeg", "image/png":
+		normalized, validationErrors, err := normalizeImageData(data, contentType)
+		if err != nil {
+			if validationErrors != nil {
+				validationErrors = attachmentRejection(validationErrors["image"], validationErrors["error_code"])
+			}
+			return nil, validationErrors, err
+		}
+		return &NormalizedAttachment{
+			Data:        normalized.Data,
+			ContentType: imageContentType(normalized.Ext),
+			FileName:    attachmentFileName(file.Filename, normalized.Ext),
+			Ext:         normalized.Ext,
+		}, nil, nil
+	default:
+		return nil, attachmentRejection(constants.MsgAttachmentTypeInvalid, constants.ErrCodeAttachmentUnsupportedType), errors.New("invalid_format")
+	}
+}
+
+func attachmentRejection(message, code string) map[string]string {
+	return map[string]string{
+		"file":       message,
+		"error_code": code,
+	}
+}
+
+// attachmentFileName membersihkan nama file dari path dan menyesuaikan
+// ekstensinya dengan isi file, misalnya foto PNG yang disimpan ulang
+// sebagai JPEG
+func attachmentFileName(name, ext string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" || name == "." || name == "/" {
+		name = "lampiran"
+	}
+
+	// Batas kolom file_name 255 karakter termasuk ekstensi
+	if runes := []rune(name); len(runes) > 255-len(ext) {
+		name = string(runes[:255-len(ext)])
+	}
+	return name + ext
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	constants "inventory_app_backend/internal/constant"
+	"strings"
+	"testing"
+)
+
+func TestAttachmentFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		ext      string
+		want     string
+	}{
+		{"nama biasa", "nota.pdf", ".pdf", "nota.pdf"},
+		{"ekstensi disesuaikan isi file", "foto.png", ".jpg", "foto.jpg"},
+		{"path unix dibuang", "../../etc/nota.pdf", ".pdf", "nota.pdf"},
+		{"path windows dibuang", `C:\Users\gudang\nota.pdf`, ".pdf", "nota.pdf"},
+		{"karakter kontrol dan kutip dibuang", "no\x00ta\r\n\"1\x7f.pdf", ".pdf", "nota1.pdf"},
+		{"nama kosong", "", ".pdf", "lampiran.pdf"},
+		{"hanya ekstensi", ".pdf", ".pdf", "lampiran.pdf"},
+		{"hanya path", "folder/", ".pdf", "folder.pdf"},
+		{"spasi di tepi", "  nota  .pdf", ".pdf", "nota.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := attachmentFileName(tt.fileName, tt.ext); got != tt.want {
+				t.Fatalf("attachmentFileName(%q, %q) = %q, want %q", tt.fileName, tt.ext, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentFileNameTruncates(t *testing.T) {
+	got := attachmentFileName(strings.Repeat("é", 300)+".pdf", ".pdf")
+	if n := len([]rune(got)); n != 255 {
+		t.Fatalf("panjang nama = %d karakter, want 255", n)
+	}
+	if !strings.HasSuffix(got, ".pdf") {
+		t.Errorf("nama = %q, ekstensi harus tetap ada", got)
+	}
+}
+
+func TestNormalizeAttachmentRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		size     int64
+		wantCode string
+	}{
+		{"file kosong", nil, 0, constants.ErrCodeAttachmentEmpty},
+		{"melebihi batas ukuran", []byte("%PDF-1.4\n"), AttachmentMaxUploadSize + 1, constants.ErrCodeAttachmentTooLarge},
+		{"file teks", []byte("ini bukan lampiran"), 0, constants.ErrCodeAttachmentUnsupportedType},
+		{"pdf palsu", []byte("\n\n%PDF-1.4 disisipkan setelah spasi"), 0, constants.ErrCodeAttachmentUnsupportedType},
+		{"gambar rusak", encodeTestPNG(t, image.NewGray(image.Rect(0, 0, 4, 4)))[:40], 0, constants.ErrCodeImageCorrupt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newFileHeader(t, "lampiran.pdf", tt.data)
+			if tt.size > 0 {
+				file.Size = tt.size
+			}
+
+			normalized, rejection, err := NormalizeAttachment(file)
+			if err == nil {
+				t.Fatalf("NormalizeAttachment() = %+v, want error", normalized)
+			}
+			if rejection["error_code"] != tt.wantCode {
+				t.Errorf("error_code = %q, want %q", rejection["error_code"], tt.wantCode)
+			}
+			if rejection["file"] == "" {
+				t.Errorf("rejection = %v, pesan harus ada di key file", rejection)
+			}
+		})
+	}
+}
+
+func TestNormalizeAttachmentAccepts(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n%%EOF\n")
+	normalized, _, err := NormalizeAttachment(newFileHeader(t, "nota.PDF", pdf))
+	if err != nil {
+		t.Fatalf("NormalizeAttachment() error = %v", err)
+	}
+	if !bytes.Equal(normalized.Data, pdf) || normalized.Ext != ".pdf" || normalized.FileName != "nota.pdf" {
+		t.Errorf("pdf = %+v, harus disimpan apa adanya", normalized)
+	}
+
+	opaque := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		opaque.Set(x, 0, color.RGBA{G: 255, A: 255})
+	}
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, opaque, nil); err != nil {
+		t.Fatal(err)
+	}
+	normalized, _, err = NormalizeAttachment(newFileHeader(t, "foto.png", jpg.Bytes()))
+	if err != nil {
+		t.Fatalf("NormalizeAttachment() error = %v", err)
+	}
+	if normalized.Ext != ".jpg" || normalized.FileName != "foto.jpg" || normalized.ContentType != "image/jpeg" {
+		t.Errorf("foto = ext %q, nama %q, tipe %q, want .jpg, foto.jpg, image/jpeg",
+			normalized.Ext, normalized.FileName, normalized.ContentType)
+	}
+
+	transparent := encodeTestPNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 4)))
+	normalized, _, err = NormalizeAttachment(newFileHeader(t, "ikon.png", transparent))
+	if err != nil {
+		t.Fatalf("NormalizeAttachment() error = %v", err)
+	}
+	if normalized.Ext != ".png" || normalized.FileName != "ikon.png" {
+		t.Errorf("ikon = ext %q, nama %q, want .png, ikon.png", normalized.Ext, normalized.FileName)
+	}
+}
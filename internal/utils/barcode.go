@@ -0,0 +1,45 @@
+package utils
+
+import (
+	constants "inventory_app_backend/internal/constant"
+	"regexp"
+)
+
+var (
+	skuPattern           = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+	ean13Pattern         = regexp.MustCompile(`^[0-9]{13}$`)
+	code128Pattern       = regexp.MustCompile(`^[\x20-\x7E]{1,48}$`)
+	customBarcodePattern = regexp.MustCompile(`^[A-Za-z0-9._\-/]{1,64}$`)
+)
+
+// IsValidSKU mengecek format SKU: huruf, angka, titik, garis bawah atau strip
+func IsValidSKU(sku string) bool {
+	return skuPattern.MatchString(sku)
+}
+
+// IsValidBarcode mengecek format kode sesuai simbologinya
+func IsValidBarcode(symbology, code string) bool {
+	switch symbology {
+	case constants.SymbologyEAN13:
+		return ean13Pattern.MatchString(code) && ean13CheckDigit(code[:12]) == code[12]
+	case constants.SymbologyCode128:
+		return code128Pattern.MatchString(code)
+	case constants.SymbologyCustom:
+		return customBarcodePattern.MatchString(code)
+	default:
+		return false
+	}
+}
+
+// ean13CheckDigit menghitung digit pemeriksa dari 12 digit pertama EAN-13
+func ean13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -1,37 +1,216 @@
 package utils
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
 	constants "inventory_app_backend/internal/constant"
 	"inventory_app_backend/pkg/firebase"
+	"io"
 	"mime/multipart"
-	"path/filepath"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+)
+
+// Batas upload dan ukuran gambar setelah dinormalisasi
+const (
+	ImageMaxUploadSize = 5 << 20 // 5MB
+	ImageMaxPixels     = 40_000_000
+	ImageMaxDimension  = 2048
+	ImageThumbnailSize = 200
+	ImageMediumSize    = 800
 )
 
-func ValidateAndUploadImage(file *multipart.FileHeader, folder string) (string, map[string]string, error) {
-	allowedExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
+// Error penolakan gambar dari NormalizeImage, detail untuk response
+// dikembalikan terpisah
+var (
+	ErrImageEmpty      = errors.New("empty_file")
+	ErrImageTooLarge   = errors.New("too_large")
+	ErrImageFormat     = errors.New("invalid_format")
+	ErrImageCorrupt    = errors.New("corrupt_image")
+	ErrImageDimensions = errors.New("dimensions_too_large")
+)
+
+// ImageVariants berisi URL gambar asli beserta versi yang diperkecil
+type ImageVariants struct {
+	Original  string
+	Medium    string
+	Thumbnail string
+}
+
+// NormalizedImage adalah gambar yang sudah divalidasi dari isinya, diputar
+// sesuai orientasi EXIF, diperkecil dan di-encode ulang tanpa metadata
+type NormalizedImage struct {
+	Image image.Image
+	Data  []byte
+	Ext   string
+}
+
+// UploadImageWithVariants mengupload gambar yang sudah dinormalisasi beserta
+// varian medium dan thumbnail. Semua varian memakai nama dasar yang sama,
+// contoh items/<uuid>.jpg, items/<uuid>_medium.jpg dan items/<uuid>_thumb.jpg.
+func UploadImageWithVariants(file *multipart.FileHeader, folder string) (ImageVariants, map[string]string, error) {
+	normalized, validationErrors, err := NormalizeImage(file)
+	if err != nil {
+		return ImageVariants{}, validationErrors, err
+	}
+
+	base := folder + "/" + uuid.New().String()
+	contentType := imageContentType(normalized.Ext)
+
+	var variants ImageVariants
+	if variants.Original, err = firebase.UploadBytes(normalized.Data, base+normalized.Ext, contentType); err != nil {
+		return ImageVariants{}, nil, fmt.Errorf("upload_failed: %w", err)
+	}
+
+	for _, variant := range []struct {
+		suffix string
+		size   int
+		url    *string
+	}{
+		{"_medium", ImageMediumSize, &variants.Medium},
+		{"_thumb", ImageThumbnailSize, &variants.Thumbnail},
+	} {
+		encoded, ext, err := encodeImage(resizeImage(normalized.Image, variant.size))
+		if err != nil {
+			return ImageVariants{}, nil, fmt.Errorf("upload_failed: %w", err)
+		}
+		url, err := firebase.UploadBytes(encoded, base+variant.suffix+ext, imageContentType(ext))
+		if err != nil {
+			return ImageVariants{}, nil, fmt.Errorf("upload_failed: %w", err)
+		}
+		*variant.url = url
 	}
-	ext := filepath.Ext(file.Filename)
-	if !allowedExtensions[ext] {
-		return "", map[string]string{
-			"image": constants.MsgImageAllowedFormat,
-		}, errors.New("invalid_format")
+
+	return variants, nil, nil
+}
+
+// NormalizeImage memvalidasi gambar berdasarkan isi file (bukan ekstensi),
+// lalu men-decode dan meng-encode ulang gambar. Metadata seperti EXIF dan
+// lokasi GPS tidak ikut tersimpan karena encoder tidak menulis metadata.
+// Setiap jenis penolakan memiliki pesan dan error_code sendiri.
+func NormalizeImage(file *multipart.FileHeader) (*NormalizedImage, map[string]string, error) {
+	if file.Size == 0 {
+		return nil, imageRejection(constants.MsgImageEmpty, constants.ErrCodeImageEmpty), ErrImageEmpty
+	}
+	if file.Size > ImageMaxUploadSize {
+		return nil, imageRejection(constants.MsgImageAllowedSizes, constants.ErrCodeImageTooLarge), ErrImageTooLarge
 	}
 
-	if file.Size > 5<<20 { // 5MB
-		return "", map[string]string{
-			"image": constants.MsgImageAllowedSizes,
-		}, errors.New("too_large")
+	src, err := file.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("upload_failed: %w", err)
 	}
+	defer src.Close()
 
-	url, err := firebase.UploadFile(file, folder)
+	data, err := io.ReadAll(io.LimitReader(src, ImageMaxUploadSize+1))
 	if err != nil {
-		return "", nil, fmt.Errorf("upload_failed: %w", err)
+		return nil, nil, fmt.Errorf("upload_failed: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, imageRejection(constants.MsgImageEmpty, constants.ErrCodeImageEmpty), ErrImageEmpty
+	}
+	if len(data) > ImageMaxUploadSize {
+		return nil, imageRejection(constants.MsgImageAllowedSizes, constants.ErrCodeImageTooLarge), ErrImageTooLarge
+	}
+
+	// Jenis file ditentukan dari magic bytes
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, imageRejection(constants.MsgImageContentInvalid, constants.ErrCodeImageUnsupportedType), ErrImageFormat
 	}
 
-	return url, nil, nil
+	return normalizeImageData(data, contentType)
+}
+
+// normalizeImageData men-decode, memutar, memperkecil dan meng-encode ulang
+// gambar yang jenisnya sudah dicek dari magic bytes
+func normalizeImageData(data []byte, contentType string) (*NormalizedImage, map[string]string, error) {
+	// Cek resolusi dari header sebelum decode penuh agar gambar raksasa
+	// tidak menghabiskan memori
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, imageRejection(constants.MsgImageDecodeFailed, constants.ErrCodeImageCorrupt), ErrImageCorrupt
+	}
+	if config.Width*config.Height > ImageMaxPixels {
+		return nil, imageRejection(constants.MsgImageDimensionsLimit, constants.ErrCodeImageDimensions), ErrImageDimensions
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, imageRejection(constants.MsgImageDecodeFailed, constants.ErrCodeImageCorrupt), ErrImageCorrupt
+	}
+
+	// Foto dari HP sering disimpan miring dengan tag orientasi EXIF. Tag
+	// ikut terbuang saat encode ulang, jadi rotasinya diterapkan ke piksel.
+	img = resizeImage(img, ImageMaxDimension)
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	encoded, ext, err := encodeImage(img)
+	if err != nil {
+		return nil, nil, fmt.Errorf("upload_failed: %w", err)
+	}
+
+	return &NormalizedImage{Image: img, Data: encoded, Ext: ext}, nil, nil
+}
+
+func imageRejection(message, code string) map[string]string {
+	return map[string]string{
+		"image":      message,
+		"error_code": code,
+	}
+}
+
+// resizeImage memperkecil gambar agar sisi terpanjang tidak melebihi
+// maxSize dengan rasio tetap. Gambar yang lebih kecil tidak diperbesar.
+func resizeImage(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxSize / width
+		width = maxSize
+	} else {
+		width = width * maxSize / height
+		height = maxSize
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+// encodeImage menyimpan gambar dengan transparansi sebagai PNG, gambar
+// lainnya sebagai JPEG
+func encodeImage(img image.Image) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		err := png.Encode(&buf, img)
+		return buf.Bytes(), ".png", err
+	}
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	return buf.Bytes(), ".jpg", err
+}
+
+func imageContentType(ext string) string {
+	if ext == ".png" {
+		return "image/png"
+	}
+	return "image/jpeg"
 }
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	constants "inventory_app_backend/internal/constant"
+	"mime/multipart"
+	"testing"
+)
+
+// newFileHeader membuat file upload multipart dari isi file
+func newFileHeader(t *testing.T, name string, data []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(10 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func encodeTestPNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pngWithDimensions mengubah ukuran di header IHDR tanpa mengubah piksel
+func pngWithDimensions(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	data := encodeTestPNG(t, image.NewGray(image.Rect(0, 0, 1, 1)))
+	// Signature 8 byte, panjang chunk 4 byte, lalu tipe IHDR dan datanya
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestNormalizeImageRejections(t *testing.T) {
+	valid := encodeTestPNG(t, image.NewGray(image.Rect(0, 0, 4, 4)))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		size     int64
+		wantErr  error
+		wantCode string
+	}{
+		{"file kosong", nil, 0, ErrImageEmpty, constants.ErrCodeImageEmpty},
+		{"melebihi batas ukuran", valid, ImageMaxUploadSize + 1, ErrImageTooLarge, constants.ErrCodeImageTooLarge},
+		{"bukan gambar", []byte("bukan gambar sama sekali"), 0, ErrImageFormat, constants.ErrCodeImageUnsupportedType},
+		{"png rusak", valid[:40], 0, ErrImageCorrupt, constants.ErrCodeImageCorrupt},
+		{"resolusi terlalu besar", pngWithDimensions(t, 10000, 10000), 0, ErrImageDimensions, constants.ErrCodeImageDimensions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newFileHeader(t, "gambar.png", tt.data)
+			if tt.size > 0 {
+				file.Size = tt.size
+			}
+
+			_, rejection, err := NormalizeImage(file)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeImage() error = %v, want %v", err, tt.wantErr)
+			}
+			if rejection["error_code"] != tt.wantCode {
+				t.Errorf("error_code = %q, want %q", rejection["error_code"], tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNormalizeImageReencodes(t *testing.T) {
+	opaque := image.NewRGBA(image.Rect(0, 0, 3000, 1500))
+	for x := 0; x < 3000; x++ {
+		opaque.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, opaque, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	normalized, _, err := NormalizeImage(newFileHeader(t, "foto.png", jpg.Bytes()))
+	if err != nil {
+		t.Fatalf("NormalizeImage() error = %v", err)
+	}
+	if normalized.Ext != ".jpg" {
+		t.Errorf("Ext = %q, want .jpg", normalized.Ext)
+	}
+	bounds := normalized.Image.Bounds()
+	if bounds.Dx() != ImageMaxDimension || bounds.Dy() != ImageMaxDimension/2 {
+		t.Errorf("ukuran = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), ImageMaxDimension, ImageMaxDimension/2)
+	}
+
+	transparent := encodeTestPNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 4)))
+	normalized, _, err = NormalizeImage(newFileHeader(t, "ikon.png", transparent))
+	if err != nil {
+		t.Fatalf("NormalizeImage() error = %v", err)
+	}
+	if normalized.Ext != ".png" {
+		t.Errorf("Ext gambar transparan = %q, want .png", normalized.Ext)
+	}
+}
@@ -1,26 +1,52 @@
 package utils
 
 import (
+	"errors"
 	"inventory_app_backend/internal/config"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// TokenPurposeTwoFactor menandai token tantangan login 2FA. Token dengan
+// purpose tidak bisa dipakai sebagai token sesi.
+const TokenPurposeTwoFactor = "2fa"
+
+// twoFactorChallengeTTL adalah batas waktu memasukkan kode 2FA setelah
+// password benar
+const twoFactorChallengeTTL = 5 * time.Minute
+
+var errTokenPurpose = errors.New("token purpose mismatch")
+
 type Claims struct {
-	UserID string `json:"userId"`
-	Role   string `json:"role"`
+	UserID  string `json:"userId"`
+	Role    string `json:"role"`
+	Purpose string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func GenerateToken(userID string, role string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
+	return signToken(userID, role, "", 24*time.Hour)
+}
+
+// GenerateChallengeToken membuat token singkat yang hanya berlaku untuk
+// menyelesaikan login 2FA
+func GenerateChallengeToken(userID string) (string, time.Duration, error) {
+	token, err := signToken(userID, "", TokenPurposeTwoFactor, twoFactorChallengeTTL)
+	return token, twoFactorChallengeTTL, err
+}
+
+func signToken(userID, role, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	expirationTime := now.Add(ttl)
 
 	claims := &Claims{
-		UserID: userID,
-		Role:   role,
+		UserID:  userID,
+		Role:    role,
+		Purpose: purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
@@ -28,14 +54,33 @@ func GenerateToken(userID string, role string) (string, error) {
 	return token.SignedString([]byte(config.Get("JWT_SECRET")))
 }
 
+// ValidateToken memvalidasi token sesi. Token tantangan 2FA ditolak.
 func ValidateToken(tokenString string) (*Claims, error) {
+	return validateToken(tokenString, "")
+}
+
+// ValidateChallengeToken memvalidasi token tantangan login 2FA
+func ValidateChallengeToken(tokenString string) (*Claims, error) {
+	return validateToken(tokenString, TokenPurposeTwoFactor)
+}
+
+func validateToken(tokenString, purpose string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(config.Get("JWT_SECRET")), nil
 	})
-
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
 	}
 
-	return nil, err
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	if claims.Purpose != purpose {
+		return nil, errTokenPurpose
+	}
+	return claims, nil
 }
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestValidateTokenPurpose(t *testing.T) {
+	t.Setenv("JWT_SECRET", "rahasia-test")
+
+	session, err := GenerateToken("user-1", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge, _, err := GenerateChallengeToken("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := ValidateToken(session)
+	if err != nil || claims.UserID != "user-1" || claims.Role != "admin" {
+		t.Fatalf("ValidateToken(sesi) = %+v, %v", claims, err)
+	}
+	if _, err := ValidateToken(challenge); !errors.Is(err, errTokenPurpose) {
+		t.Errorf("ValidateToken(tantangan) error = %v, want %v", err, errTokenPurpose)
+	}
+	if _, err := ValidateChallengeToken(session); !errors.Is(err, errTokenPurpose) {
+		t.Errorf("ValidateChallengeToken(sesi) error = %v, want %v", err, errTokenPurpose)
+	}
+	if _, err := ValidateChallengeToken(challenge); err != nil {
+		t.Errorf("ValidateChallengeToken(tantangan) error = %v", err)
+	}
+}
+
+func TestValidateTokenRejectsInvalid(t *testing.T) {
+	t.Setenv("JWT_SECRET", "rahasia-test")
+
+	session, err := GenerateToken("user-1", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := signToken("user-1", "admin", "", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Payload token lain dengan signature token sesi
+	tampered := strings.Split(session, ".")
+	tampered[1] = strings.Split(expired, ".")[1]
+
+	otherSecret, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: "user-1"}).
+		SignedString([]byte("rahasia-lain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"token kosong", ""},
+		{"bukan jwt", "bukan.token.jwt"},
+		{"payload diubah", strings.Join(tampered, ".")},
+		{"sudah kedaluwarsa", expired},
+		{"secret berbeda", otherSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ValidateToken(tt.token)
+			if err == nil || claims != nil {
+				t.Fatalf("ValidateToken() = %+v, %v, want error", claims, err)
+			}
+		})
+	}
+}
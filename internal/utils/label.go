@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	constants "inventory_app_backend/internal/constant"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+)
+
+// ErrLabelTooSmall dikembalikan jika ukuran label tidak cukup untuk kode
+var ErrLabelTooSmall = errors.New("label_too_small")
+
+// Label berisi data yang dicetak pada satu label
+type Label struct {
+	Name string
+	SKU  string
+	Unit string
+	Code string
+}
+
+// LabelSheet mengatur grid label pada satu halaman PDF
+type LabelSheet struct {
+	PageSize  string
+	Columns   int
+	Rows      int
+	MarginMM  float64
+	GapMM     float64
+	Skip      int
+	Symbology string
+}
+
+// LabelRoll mengatur ukuran satu label pada printer thermal (ZPL)
+type LabelRoll struct {
+	WidthMM   float64
+	HeightMM  float64
+	DPI       int
+	Copies    int
+	Symbology string
+}
+
+const (
+	labelPaddingMM  = 2
+	labelNameLineMM = 4
+	labelTextLineMM = 3
+	labelMinCodeMM  = 5
+)
+
+// RenderLabelPDF menyusun label dalam grid dan menulis PDF ke w. Skip
+// melewati sejumlah posisi pertama agar lembar label sisa bisa dipakai.
+func RenderLabelPDF(w io.Writer, labels []Label, sheet LabelSheet) error {
+	pdf := fpdf.New("P", "mm", sheet.PageSize, "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageW, pageH := pdf.GetPageSize()
+	cols, rows := float64(sheet.Columns), float64(sheet.Rows)
+	labelW := (pageW - 2*sheet.MarginMM - sheet.GapMM*(cols-1)) / cols
+	labelH := (pageH - 2*sheet.MarginMM - sheet.GapMM*(rows-1)) / rows
+
+	minHeight := float64(2*labelPaddingMM + labelMinCodeMM)
+	if sheet.Symbology == constants.LabelSymbologyCode128 {
+		minHeight += labelNameLineMM + 2*labelTextLineMM
+	}
+	if labelW < 20 || labelH < minHeight {
+		return ErrLabelTooSmall
+	}
+
+	perPage := sheet.Columns * sheet.Rows
+	skip := sheet.Skip % perPage
+	images := map[string]bool{}
+
+	for i, label := range labels {
+		slot := (i + skip) % perPage
+		if i == 0 || slot == 0 {
+			pdf.AddPage()
+		}
+
+		imageName := sheet.Symbology + ":" + label.Code
+		if !images[imageName] {
+			bc, err := encodeLabelCode(label.Code, sheet.Symbology)
+			if err != nil {
+				return err
+			}
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, bc); err != nil {
+				return err
+			}
+			pdf.RegisterImageOptionsReader(imageName, fpdf.ImageOptions{ImageType: "PNG"}, &buf)
+			images[imageName] = true
+		}
+
+		x := sheet.MarginMM + float64(slot%sheet.Columns)*(labelW+sheet.GapMM)
+		y := sheet.MarginMM + float64(slot/sheet.Columns)*(labelH+sheet.GapMM)
+		innerW := labelW - 2*labelPaddingMM
+		innerX := x + labelPaddingMM
+		innerY := y + labelPaddingMM
+
+		if sheet.Symbology == constants.LabelSymbologyQR {
+			// QR di kiri, teks di kanan
+			size := math.Min(labelH-2*labelPaddingMM, innerW/2)
+			pdf.ImageOptions(imageName, innerX, innerY, size, size, false, fpdf.ImageOptions{}, 0, "")
+
+			textX := innerX + size + labelPaddingMM
+			textW := innerW - size - labelPaddingMM
+			pdf.SetFont("Helvetica", "B", 8)
+			pdf.SetXY(textX, innerY)
+			pdf.CellFormat(textW, labelNameLineMM, fitLabelText(pdf, tr(label.Name), textW), "", 2, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 6.5)
+			pdf.SetX(textX)
+			pdf.CellFormat(textW, labelTextLineMM, fitLabelText(pdf, tr("SKU: "+labelValue(label.SKU)), textW), "", 2, "L", false, 0, "")
+			pdf.SetX(textX)
+			pdf.CellFormat(textW, labelTextLineMM, fitLabelText(pdf, tr("Satuan: "+label.Unit), textW), "", 2, "L", false, 0, "")
+			if label.Code != label.SKU {
+				pdf.SetX(textX)
+				pdf.CellFormat(textW, labelTextLineMM, fitLabelText(pdf, label.Code, textW), "", 2, "L", false, 0, "")
+			}
+			continue
+		}
+
+		// Code128: nama di atas, barcode di tengah, kode dan detail di bawah
+		codeH := labelH - 2*labelPaddingMM - labelNameLineMM - 2*labelTextLineMM
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.SetXY(innerX, innerY)
+		pdf.CellFormat(innerW, labelNameLineMM, fitLabelText(pdf, tr(label.Name), innerW), "", 2, "L", false, 0, "")
+		pdf.ImageOptions(imageName, innerX, innerY+labelNameLineMM, innerW, codeH, false, fpdf.ImageOptions{}, 0, "")
+		pdf.SetFont("Helvetica", "", 6.5)
+		pdf.SetXY(innerX, innerY+labelNameLineMM+codeH)
+		pdf.CellFormat(innerW, labelTextLineMM, fitLabelText(pdf, label.Code, innerW), "", 2, "C", false, 0, "")
+		pdf.SetX(innerX)
+		detail := fmt.Sprintf("SKU: %s | Satuan: %s", labelValue(label.SKU), label.Unit)
+		pdf.CellFormat(innerW, labelTextLineMM, fitLabelText(pdf, tr(detail), innerW), "", 2, "L", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
+
+// RenderLabelZPL menulis satu format ZPL per label untuk printer thermal
+func RenderLabelZPL(w io.Writer, labels []Label, roll LabelRoll) error {
+	dots := func(mm float64) int {
+		return int(math.Round(mm * float64(roll.DPI) / 25.4))
+	}
+	width, height := dots(roll.WidthMM), dots(roll.HeightMM)
+	pad := dots(labelPaddingMM)
+	nameH := height / 6
+	textH := height / 9
+	module := roll.DPI / 100
+
+	var b strings.Builder
+	for _, label := range labels {
+		b.WriteString("^XA^CI28\n")
+		fmt.Fprintf(&b, "^PW%d^LL%d^LH0,0\n", width, height)
+
+		if roll.Symbology == constants.LabelSymbologyQR {
+			// Pembesaran dihitung dari jumlah modul QR agar muat di label
+			bc, err := qr.Encode(label.Code, qr.M, qr.Auto)
+			if err != nil {
+				return err
+			}
+			modules := bc.Bounds().Dx()
+			size := int(math.Min(float64(height-2*pad), float64(width-2*pad)/2))
+			magnification := size / modules
+			if magnification < 1 {
+				return ErrLabelTooSmall
+			}
+			if magnification > 10 {
+				magnification = 10
+			}
+			fmt.Fprintf(&b, "^FO%d,%d^BQN,2,%d^FH_^FDQA,%s^FS\n", pad, pad, magnification, zplEscape(label.Code))
+
+			textX := pad + modules*magnification + pad
+			textW := width - textX - pad
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,2,0,L^FH_^FD%s^FS\n", textX, pad, nameH, nameH, textW, zplEscape(label.Name))
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,L^FH_^FDSKU: %s^FS\n", textX, pad+2*nameH+pad, textH, textH, textW, zplEscape(labelValue(label.SKU)))
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,L^FH_^FDSatuan: %s^FS\n", textX, pad+2*nameH+pad+textH+pad/2, textH, textH, textW, zplEscape(label.Unit))
+		} else {
+			codeH := height - 3*pad - nameH - 2*textH
+			if codeH < dots(labelMinCodeMM) {
+				return ErrLabelTooSmall
+			}
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,L^FH_^FD%s^FS\n", pad, pad, nameH, nameH, width-2*pad, zplEscape(label.Name))
+			fmt.Fprintf(&b, "^FO%d,%d^BY%d^BCN,%d,Y,N,N^FH_^FD%s^FS\n", pad, pad+nameH+pad/2, module, codeH, zplEscape(label.Code))
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,L^FH_^FDSKU: %s | Satuan: %s^FS\n", pad, height-pad-textH, textH, textH, width-2*pad, zplEscape(labelValue(label.SKU)), zplEscape(label.Unit))
+		}
+
+		fmt.Fprintf(&b, "^PQ%d\n^XZ\n", roll.Copies)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func encodeLabelCode(code, symbology string) (barcode.Barcode, error) {
+	if symbology == constants.LabelSymbologyQR {
+		bc, err := qr.Encode(code, qr.M, qr.Auto)
+		if err != nil {
+			return nil, err
+		}
+		return barcode.Scale(bc, bc.Bounds().Dx()*8, bc.Bounds().Dy()*8)
+	}
+
+	bc, err := code128.Encode(code)
+	if err != nil {
+		return nil, err
+	}
+	return barcode.Scale(bc, bc.Bounds().Dx()*2, 40)
+}
+
+// fitLabelText memotong teks agar muat dalam lebar label. Teks sudah
+// diterjemahkan ke cp1252 sehingga satu karakter adalah satu byte.
+func fitLabelText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+// zplEscape mengubah karakter kontrol ZPL menjadi hex untuk dipakai dengan ^FH_
+func zplEscape(value string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(value)
+}
+
+func labelValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const MaxSpreadsheetSize = 5 << 20 // 5MB
+
+var (
+	ErrSpreadsheetFormat   = errors.New("invalid_format")
+	ErrSpreadsheetTooLarge = errors.New("too_large")
+	ErrSpreadsheetEmpty    = errors.New("empty")
+)
+
+// ReadSpreadsheet membaca file xlsx atau csv dan mengembalikan baris header
+// (lowercase) serta baris data. Sel xlsx dibaca sebagai nilai mentah sehingga
+// tanggal berupa angka serial Excel, gunakan ParseSpreadsheetDate.
+func ReadSpreadsheet(file *multipart.FileHeader) ([]string, [][]string, error) {
+	if file.Size > MaxSpreadsheetSize {
+		return nil, nil, ErrSpreadsheetTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer src.Close()
+
+	var rows [][]string
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".xlsx":
+		f, err := excelize.OpenReader(src, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, nil, ErrSpreadsheetFormat
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil, ErrSpreadsheetEmpty
+		}
+		rows, err = f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, nil, err
+		}
+	case ".csv":
+		reader := csv.NewReader(src)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, ErrSpreadsheetFormat
+			}
+			rows = append(rows, record)
+		}
+	default:
+		return nil, nil, ErrSpreadsheetFormat
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, ErrSpreadsheetEmpty
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, col := range rows[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+	}
+
+	return header, rows[1:], nil
+}
+
+// SpreadsheetCell mengambil nilai kolom berdasarkan nama header
+func SpreadsheetCell(header []string, row []string, column string) string {
+	for i, name := range header {
+		if name == column && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+	}
+	return ""
+}
+
+// IsBlankRow mengecek apakah semua sel pada baris kosong
+func IsBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSpreadsheetDate menerima format YYYY-MM-DD atau angka serial tanggal Excel
+func ParseSpreadsheetDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	date, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// Secret ASCII "12345678901234567890" dari lampiran B RFC 6238
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// Kode 8 digit di RFC dipotong menjadi 6 digit terakhir
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode(T=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTPWindow(t *testing.T) {
+	key := []byte("12345678901234567890")
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name   string
+		step   int64
+		wantOK bool
+	}{
+		{"periode sekarang", current, true},
+		{"satu periode sebelumnya", current - 1, true},
+		{"satu periode sesudahnya", current + 1, true},
+		{"dua periode sebelumnya", current - 2, false},
+		{"dua periode sesudahnya", current + 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfcTOTPSecret, totpCode(key, tt.step), now)
+			if ok != tt.wantOK {
+				t.Fatalf("ValidateTOTP() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.step {
+				t.Errorf("ValidateTOTP() step = %d, want %d", step, tt.step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsMalformed(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{"kode terlalu pendek", rfcTOTPSecret, "50471"},
+		{"kode terlalu panjang", rfcTOTPSecret, "14050471"},
+		{"kode kosong", rfcTOTPSecret, ""},
+		{"secret bukan base32", "bukan-base32!", "050471"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(tt.secret, tt.code, now); ok {
+				t.Fatalf("ValidateTOTP(%q, %q) diterima, want ditolak", tt.secret, tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPAcceptsFormattedInput(t *testing.T) {
+	// Secret huruf kecil dan kode dengan spasi di tepi tetap diterima
+	now := time.Unix(1111111111, 0)
+	if _, ok := ValidateTOTP("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", " 050471 ", now); !ok {
+		t.Fatal("ValidateTOTP() ditolak, want diterima")
+	}
+}
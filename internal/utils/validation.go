@@ -30,15 +30,3 @@ func ParseValidationError(err error) gin.H {
 	}
 	return gin.H{"validation": errors}
 }
-
-// Validasi role users
-func IsValidRole(role string) bool {
-	switch role {
-	case constants.RoleAdmin,
-		constants.RoleWarehouseAdmin,
-		constants.RoleWarehouseManager:
-		return true
-	default:
-		return false
-	}
-}
@@ -0,0 +1,122 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"inventory_app_backend/internal/config"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	gcsStorage "cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	defaultSignedURLTTL = time.Hour
+	maxSignedURLTTL     = 7 * 24 * time.Hour // batas signed URL V4
+	signedURLCacheLimit = 10000
+)
+
+type signedURL struct {
+	url     string
+	expires time.Time
+}
+
+var (
+	signedURLMu    sync.Mutex
+	signedURLCache = make(map[string]signedURL)
+)
+
+// SignedURL membuat URL sementara untuk membaca objek private. URL yang sama
+// dipakai ulang selama masa berlakunya masih lebih dari setengah agar
+// browser tetap bisa memakai cache gambar.
+func SignedURL(objectPath string) (string, error) {
+	ttl := signedURLTTL()
+	now := time.Now()
+
+	signedURLMu.Lock()
+	cached, ok := signedURLCache[objectPath]
+	signedURLMu.Unlock()
+	if ok && cached.expires.Sub(now) > ttl/2 {
+		return cached.url, nil
+	}
+
+	bucket, err := StorageClient.Bucket(config.Get("FIREBASE_BUCKET_NAME"))
+	if err != nil {
+		return "", err
+	}
+
+	expires := now.Add(ttl)
+	url, err := bucket.SignedURL(objectPath, &gcsStorage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: expires,
+		Scheme:  gcsStorage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signedURLMu.Lock()
+	if len(signedURLCache) >= signedURLCacheLimit {
+		for path, entry := range signedURLCache {
+			if entry.expires.Sub(now) <= ttl/2 {
+				delete(signedURLCache, path)
+			}
+		}
+	}
+	signedURLCache[objectPath] = signedURL{url: url, expires: expires}
+	signedURLMu.Unlock()
+
+	return url, nil
+}
+
+// SignedDownloadURL membuat URL sementara yang membuat browser mengunduh
+// objek dengan nama file aslinya. URL ini tidak di-cache.
+func SignedDownloadURL(objectPath, fileName string) (string, error) {
+	bucket, err := StorageClient.Bucket(config.Get("FIREBASE_BUCKET_NAME"))
+	if err != nil {
+		return "", err
+	}
+
+	return bucket.SignedURL(objectPath, &gcsStorage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(signedURLTTL()),
+		Scheme:  gcsStorage.SigningSchemeV4,
+		QueryParameters: url.Values{
+			"response-content-disposition": {mime.FormatMediaType("attachment", map[string]string{"filename": fileName})},
+		},
+	})
+}
+
+// MakePrivate mencabut akses publik (AllUsers) dari objek yang diupload
+// sebelum bucket dibuat private
+func MakePrivate(objectPath string) error {
+	bucket, err := StorageClient.Bucket(config.Get("FIREBASE_BUCKET_NAME"))
+	if err != nil {
+		return err
+	}
+
+	err = bucket.Object(objectPath).ACL().Delete(context.Background(), gcsStorage.AllUsers)
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		// Objek memang tidak publik
+		return nil
+	}
+	return err
+}
+
+func signedURLTTL() time.Duration {
+	minutes, err := strconv.Atoi(config.Get("IMAGE_URL_TTL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		return defaultSignedURLTTL
+	}
+	ttl := time.Duration(minutes) * time.Minute
+	if ttl > maxSignedURLTTL {
+		return maxSignedURLTTL
+	}
+	return ttl
+}
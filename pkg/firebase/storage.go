@@ -1,20 +1,21 @@
 package firebase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"inventory_app_backend/internal/config"
 	"io"
-	"mime"
-	"mime/multipart"
 	"os"
-	"path/filepath"
+	"strings"
+	"time"
 
 	gcsStorage "cloud.google.com/go/storage"
 	firebase "firebase.google.com/go/v4"
 	firebaseStorage "firebase.google.com/go/v4/storage"
 
-	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -38,33 +39,28 @@ func InitializeStorage() error {
 	return err
 }
 
-func UploadFile(fileHeader *multipart.FileHeader, folder string) (string, error) {
+// UploadBytes menyimpan data ke path objek yang sudah ditentukan. File dari
+// user selalu divalidasi dan diolah server lebih dulu, misalnya gambar yang
+// di-encode ulang, sehingga tidak ada upload file mentah.
+func UploadBytes(data []byte, objectPath, contentType string) (string, error) {
+	return upload(bytes.NewReader(data), objectPath, contentType)
+}
+
+func upload(r io.Reader, objectPath, contentType string) (string, error) {
 	ctx := context.Background()
 	bucketName := config.Get("FIREBASE_BUCKET_NAME")
 
-	// Generate unique filename
-	extension := filepath.Ext(fileHeader.Filename)
-	fileName := uuid.New().String() + extension
-	contentType := mime.TypeByExtension(extension)
-
-	// Open file
-	file, err := fileHeader.Open()
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
 	// Upload file
 	bucket, err := StorageClient.Bucket(bucketName)
 	if err != nil {
 		return "", err
 	}
 
-	obj := bucket.Object(folder + "/" + fileName)
+	obj := bucket.Object(objectPath)
 	wc := obj.NewWriter(ctx)
 	wc.ContentType = contentType
 
-	if _, err = io.Copy(wc, file); err != nil {
+	if _, err = io.Copy(wc, r); err != nil {
 		return "", err
 	}
 
@@ -72,11 +68,66 @@ func UploadFile(fileHeader *multipart.FileHeader, folder string) (string, error)
 		return "", err
 	}
 
-	// Buat objek bisa diakses publik
-	if err := obj.ACL().Set(ctx, gcsStorage.AllUsers, gcsStorage.RoleReader); err != nil {
-		return "", err
+	// Objek tetap private, URL ini hanya penanda lokasi objek dan diubah
+	// menjadi signed URL saat dikirim ke client
+	objectURL := fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucketName, objectPath)
+	return objectURL, nil
+}
+
+// ObjectInfo adalah ringkasan objek di bucket
+type ObjectInfo struct {
+	Path    string
+	Size    int64
+	Created time.Time
+}
+
+// ObjectPath mengubah URL objek menjadi path objek di bucket. Mengembalikan
+// string kosong jika URL bukan milik bucket aplikasi.
+func ObjectPath(url string) string {
+	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", config.Get("FIREBASE_BUCKET_NAME"))
+	if !strings.HasPrefix(url, prefix) {
+		return ""
 	}
+	return strings.TrimPrefix(url, prefix)
+}
 
-	publicURL := fmt.Sprintf("https://storage.googleapis.com/%s/%s/%s", bucketName, folder, fileName)
-	return publicURL, nil
+// DeleteObject menghapus objek dari bucket. Objek yang sudah tidak ada
+// dianggap berhasil dihapus.
+func DeleteObject(objectPath string) error {
+	bucket, err := StorageClient.Bucket(config.Get("FIREBASE_BUCKET_NAME"))
+	if err != nil {
+		return err
+	}
+
+	err = bucket.Object(objectPath).Delete(context.Background())
+	if errors.Is(err, gcsStorage.ErrObjectNotExist) {
+		return nil
+	}
+	return err
+}
+
+// ListObjects mengambil seluruh objek dengan prefix tertentu
+func ListObjects(prefix string) ([]ObjectInfo, error) {
+	bucket, err := StorageClient.Bucket(config.Get("FIREBASE_BUCKET_NAME"))
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []ObjectInfo
+	it := bucket.Objects(context.Background(), &gcsStorage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, ObjectInfo{
+			Path:    attrs.Name,
+			Size:    attrs.Size,
+			Created: attrs.Created,
+		})
+	}
+	return objects, nil
 }
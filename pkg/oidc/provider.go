@@ -0,0 +1,265 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory_app_backend/internal/config"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	httpTimeout   = 10 * time.Second
+	defaultScopes = "openid profile email"
+)
+
+var (
+	// ErrDisabled dikembalikan jika OIDC_ISSUER_URL tidak diatur
+	ErrDisabled = errors.New("oidc_disabled")
+	// ErrInvalidIDToken dikembalikan jika ID token gagal diverifikasi
+	ErrInvalidIDToken = errors.New("oidc_invalid_id_token")
+)
+
+// Config berisi pengaturan client OIDC yang terdaftar di identity provider
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Claims adalah isi ID token, dilengkapi userinfo untuk claim yang tidak
+// ada di ID token
+type Claims map[string]interface{}
+
+// String mengambil claim bertipe string. Claim yang tidak ada atau bukan
+// string menghasilkan string kosong.
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Strings mengambil claim berupa daftar string. Claim string tunggal
+// dianggap daftar berisi satu nilai.
+func (c Claims) Strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider adalah client authorization code flow dengan PKCE untuk satu
+// issuer
+type Provider struct {
+	config   Config
+	metadata metadata
+	oauth2   *oauth2.Config
+	client   *http.Client
+	keys     *keySet
+}
+
+// NewProvider membaca discovery document issuer. Issuer di dokumen harus sama
+// dengan yang dikonfigurasi agar token dari issuer lain tidak diterima.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	issuer := strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	var meta metadata
+	if err := getJSON(ctx, client, issuer+"/.well-known/openid-configuration", "", &meta); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q tidak sama dengan %q", meta.Issuer, cfg.IssuerURL)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: endpoint tidak lengkap")
+	}
+
+	return &Provider{
+		config:   cfg,
+		metadata: meta,
+		oauth2: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  meta.AuthorizationEndpoint,
+				TokenURL: meta.TokenEndpoint,
+			},
+		},
+		client: client,
+		keys:   newKeySet(client, meta.JWKSURI),
+	}, nil
+}
+
+// AuthCodeURL menyusun URL login di identity provider dengan challenge PKCE
+// S256 dari verifier
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	return p.oauth2.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	)
+}
+
+// Exchange menukar authorization code dengan token, memverifikasi ID token
+// dan nonce, lalu melengkapi claim dari endpoint userinfo
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (Claims, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %w", err)
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: id_token tidak ada", ErrInvalidIDToken)
+	}
+	claims, err := p.verifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.String("nonce") != nonce {
+		return nil, fmt.Errorf("%w: nonce tidak cocok", ErrInvalidIDToken)
+	}
+
+	if p.metadata.UserinfoEndpoint != "" {
+		var userinfo Claims
+		if err := getJSON(ctx, p.client, p.metadata.UserinfoEndpoint, token.AccessToken, &userinfo); err != nil {
+			return nil, fmt.Errorf("oidc userinfo: %w", err)
+		}
+		// Userinfo hanya boleh dipakai jika subject-nya sama dengan ID token
+		if userinfo.String("sub") != claims.String("sub") {
+			return nil, fmt.Errorf("%w: subject userinfo tidak cocok", ErrInvalidIDToken)
+		}
+		for name, value := range userinfo {
+			if _, exists := claims[name]; !exists {
+				claims[name] = value
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken string) (Claims, error) {
+	var claims jwt.MapClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.keys.key(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.metadata.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	// Jika token untuk beberapa audience, azp wajib berisi client ini
+	audience, _ := claims.GetAudience()
+	if len(audience) > 1 {
+		if azp, _ := claims["azp"].(string); azp != p.config.ClientID {
+			return nil, fmt.Errorf("%w: azp tidak cocok", ErrInvalidIDToken)
+		}
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, fmt.Errorf("%w: sub kosong", ErrInvalidIDToken)
+	}
+
+	return Claims(claims), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url, accessToken string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+var (
+	defaultMu       sync.Mutex
+	defaultProvider *Provider
+)
+
+// Enabled mengecek apakah SSO diaktifkan lewat konfigurasi
+func Enabled() bool {
+	return config.Get("OIDC_ISSUER_URL") != ""
+}
+
+// Default mengembalikan provider dari konfigurasi OIDC_*. Discovery baru
+// dilakukan saat pertama dipakai dan diulang jika gagal, sehingga API tetap
+// bisa start walaupun identity provider sedang tidak bisa dihubungi.
+func Default(ctx context.Context) (*Provider, error) {
+	if !Enabled() {
+		return nil, ErrDisabled
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultProvider != nil {
+		return defaultProvider, nil
+	}
+
+	scopes := config.Get("OIDC_SCOPES")
+	if scopes == "" {
+		scopes = defaultScopes
+	}
+	provider, err := NewProvider(ctx, Config{
+		IssuerURL:    config.Get("OIDC_ISSUER_URL"),
+		ClientID:     config.Get("OIDC_CLIENT_ID"),
+		ClientSecret: config.Get("OIDC_CLIENT_SECRET"),
+		RedirectURL:  config.Get("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(scopes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defaultProvider = provider
+	return defaultProvider, nil
+}
@@ -0,0 +1,279 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "inventory"
+	testCode     = "kode-otorisasi"
+	testNonce    = "nonce-login"
+	testVerifier = "verifier-pkce-yang-cukup-panjang-untuk-rfc-7636-minimal-43"
+)
+
+// mockIssuer adalah identity provider palsu yang melayani discovery, JWKS,
+// token dan userinfo
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// challenge adalah code_challenge dari URL login
+	challenge string
+	// claims diubah per test untuk membuat ID token yang tidak valid
+	claims   jwt.MapClaims
+	userinfo map[string]interface{}
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mockIssuer{t: t, key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("/jwks", m.jwks)
+	mux.HandleFunc("/token", m.token)
+	mux.HandleFunc("/userinfo", m.userinfoHandler)
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	now := time.Now()
+	m.claims = jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   testClientID,
+		"sub":   "subject-1",
+		"nonce": testNonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"email": "budi@example.com",
+	}
+	m.userinfo = map[string]interface{}{
+		"sub":    "subject-1",
+		"name":   "Budi",
+		"email":  "lain@example.com",
+		"groups": []string{"gudang"},
+	}
+	return m
+}
+
+func (m *mockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 m.server.URL,
+		"authorization_endpoint": m.server.URL + "/authorize",
+		"token_endpoint":         m.server.URL + "/token",
+		"userinfo_endpoint":      m.server.URL + "/userinfo",
+		"jwks_uri":               m.server.URL + "/jwks",
+	})
+}
+
+func (m *mockIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "kunci-1",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Verifier harus menghasilkan challenge yang dikirim saat login
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if r.PostForm.Get("code") != testCode || base64.RawURLEncoding.EncodeToString(sum[:]) != m.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims)
+	token.Header["kid"] = "kunci-1"
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		m.t.Error(err)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (m *mockIssuer) userinfoHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, m.userinfo)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// login menjalankan alur lengkap: discovery, URL login, lalu exchange
+func (m *mockIssuer) login(verifier string) (Claims, error) {
+	m.t.Helper()
+	ctx := context.Background()
+	provider, err := NewProvider(ctx, Config{
+		IssuerURL:   m.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/callback",
+		Scopes:      []string{"openid"},
+	})
+	if err != nil {
+		m.t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state", testNonce, testVerifier))
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	query := authURL.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("nonce") != testNonce {
+		m.t.Fatalf("URL login = %s, want challenge S256 dan nonce", authURL)
+	}
+	m.challenge = query.Get("code_challenge")
+
+	return provider.Exchange(ctx, testCode, verifier, testNonce)
+}
+
+func TestExchange(t *testing.T) {
+	m := newMockIssuer(t)
+
+	claims, err := m.login(testVerifier)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if claims.String("sub") != "subject-1" || claims.String("name") != "Budi" {
+		t.Errorf("claims = %v, want sub dan name dari userinfo", claims)
+	}
+	// Claim ID token tidak ditimpa userinfo
+	if claims.String("email") != "budi@example.com" {
+		t.Errorf("email = %q, want budi@example.com", claims.String("email"))
+	}
+	if groups := claims.Strings("groups"); len(groups) != 1 || groups[0] != "gudang" {
+		t.Errorf("groups = %v, want [gudang]", groups)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	m := newMockIssuer(t)
+
+	_, err := m.login("verifier-lain-yang-tidak-cocok-dengan-challenge-login-awal")
+	if err == nil {
+		t.Fatal("Exchange() diterima, want ditolak karena verifier PKCE salah")
+	}
+	if errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("error = %v, want kegagalan token exchange", err)
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(m *mockIssuer)
+	}{
+		{
+			name:   "nonce berbeda dari sesi login",
+			modify: func(m *mockIssuer) { m.claims["nonce"] = "nonce-lain" },
+		},
+		{
+			name:   "audience client lain",
+			modify: func(m *mockIssuer) { m.claims["aud"] = "client-lain" },
+		},
+		{
+			name:   "issuer lain",
+			modify: func(m *mockIssuer) { m.claims["iss"] = "https://issuer-lain.example.com" },
+		},
+		{
+			name: "beberapa audience tanpa azp",
+			modify: func(m *mockIssuer) {
+				m.claims["aud"] = []string{testClientID, "client-lain"}
+			},
+		},
+		{
+			name: "beberapa audience dengan azp client lain",
+			modify: func(m *mockIssuer) {
+				m.claims["aud"] = []string{testClientID, "client-lain"}
+				m.claims["azp"] = "client-lain"
+			},
+		},
+		{
+			name:   "sudah kedaluwarsa",
+			modify: func(m *mockIssuer) { m.claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		},
+		{
+			name:   "subject kosong",
+			modify: func(m *mockIssuer) { delete(m.claims, "sub") },
+		},
+		{
+			name:   "subject userinfo berbeda",
+			modify: func(m *mockIssuer) { m.userinfo["sub"] = "subject-lain" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockIssuer(t)
+			tt.modify(m)
+
+			_, err := m.login(testVerifier)
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Fatalf("Exchange() error = %v, want %v", err, ErrInvalidIDToken)
+			}
+		})
+	}
+}
+
+func TestExchangeAcceptsMatchingAZP(t *testing.T) {
+	m := newMockIssuer(t)
+	m.claims["aud"] = []string{testClientID, "client-lain"}
+	m.claims["azp"] = testClientID
+
+	if _, err := m.login(testVerifier); err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+}
+
+func TestNewProviderRejectsIssuerMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 "https://issuer-lain.example.com",
+			"authorization_endpoint": "https://issuer-lain.example.com/authorize",
+			"token_endpoint":         "https://issuer-lain.example.com/token",
+			"jwks_uri":               "https://issuer-lain.example.com/jwks",
+		})
+	}))
+	defer server.Close()
+
+	if _, err := NewProvider(context.Background(), Config{IssuerURL: server.URL, ClientID: testClientID}); err == nil {
+		t.Fatal("NewProvider() diterima, want ditolak karena issuer berbeda")
+	}
+}
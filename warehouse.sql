@@ -2,29 +2,145 @@
 CREATE DATABASE IF NOT EXISTS warehouse;
 USE warehouse;
 
+-- Tabel `role`
+CREATE TABLE roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255),
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    require_two_factor BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Tabel `izin akses`, daftar izin yang dicek oleh route
+CREATE TABLE permissions (
+    permission VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+-- Tabel `izin per role`
+CREATE TABLE role_permissions (
+    role_name VARCHAR(50) NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role_name, permission),
+    FOREIGN KEY (role_name) REFERENCES roles(name) ON DELETE CASCADE,
+    FOREIGN KEY (permission) REFERENCES permissions(permission) ON DELETE CASCADE
+);
+
 -- Tabel `users`
 CREATE TABLE users (
     user_id char(36) PRIMARY KEY,
     username VARCHAR(255) UNIQUE NOT NULL,
     password VARCHAR(255) NOT NULL,
     full_name VARCHAR(255),
-    role ENUM('admin', 'warehouse_admin', 'warehouse_manager') NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    is_service_account BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+    password_changed_at TIMESTAMP NULL,
+    totp_secret VARCHAR(64) NULL,
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_enabled_at TIMESTAMP NULL,
+    totp_last_step BIGINT NOT NULL DEFAULT 0,
+    -- User SSO tidak punya password lokal (password kosong)
+    auth_provider VARCHAR(20) NOT NULL DEFAULT 'local',
+    external_subject VARCHAR(255) NULL UNIQUE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE RESTRICT
+);
+
+-- Tabel `kode pemulihan 2FA`, kode disimpan sebagai hash SHA-256 dan hanya
+-- bisa dipakai sekali
+CREATE TABLE user_recovery_codes (
+    code_id char(36) PRIMARY KEY,
+    user_id char(36) NOT NULL,
+    code_hash char(64) NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_recovery_codes_user (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- Tabel `sesi login SSO`, menyimpan state, nonce dan PKCE verifier sampai
+-- callback dari identity provider diterima
+CREATE TABLE oidc_login_states (
+    state VARCHAR(64) PRIMARY KEY,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tabel `percobaan login gagal` per username (user:<username>) dan per IP (ip:<ip>)
+CREATE TABLE login_throttles (
+    throttle_key VARCHAR(300) PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failure_at TIMESTAMP NULL
+);
+
+-- Tabel `api key`, kunci disimpan sebagai hash SHA-256
+-- Setiap kunci terikat ke satu service account (users.is_service_account)
+CREATE TABLE api_keys (
+    key_id char(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash char(64) NOT NULL UNIQUE,
+    user_id char(36) NOT NULL,
+    allowed_ips TEXT,
+    expires_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP NULL,
+    created_by char(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT,
+    FOREIGN KEY (created_by) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+-- Tabel `izin per api key`
+CREATE TABLE api_key_permissions (
+    key_id char(36) NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (key_id, permission),
+    FOREIGN KEY (key_id) REFERENCES api_keys(key_id) ON DELETE CASCADE,
+    FOREIGN KEY (permission) REFERENCES permissions(permission) ON DELETE CASCADE
 );
 
 -- Tabel `jenis_barang`
 CREATE TABLE item_types (
     type_id char(36) PRIMARY KEY,
+    parent_id char(36) NULL DEFAULT NULL,
     type_name VARCHAR(255) UNIQUE NOT NULL,
+    stock_policy ENUM('strict', 'current_only') NOT NULL DEFAULT 'strict',
+    archived_at TIMESTAMP NULL DEFAULT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (parent_id) REFERENCES item_types(type_id) ON DELETE RESTRICT
+);
+
+-- Tabel `atribut jenis barang`
+CREATE TABLE item_type_attributes (
+    attribute_id char(36) PRIMARY KEY,
+    type_id char(36) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    data_type ENUM('text', 'number', 'enum', 'date') NOT NULL,
+    options TEXT,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_type_attribute_name (type_id, name),
+    FOREIGN KEY (type_id) REFERENCES item_types(type_id) ON DELETE CASCADE
 );
 
 -- Tabel `satuan`
 CREATE TABLE units (
     unit_id char(36) PRIMARY KEY,
     unit_name VARCHAR(255) UNIQUE NOT NULL,
+    archived_at TIMESTAMP NULL DEFAULT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
 );
@@ -35,15 +151,82 @@ CREATE TABLE items (
     type_id char(36) NOT NULL,
     unit_id char(36) NOT NULL,
     item_name VARCHAR(255) NOT NULL,
+    sku VARCHAR(64) UNIQUE,
     stock INT NOT NULL DEFAULT 0,
     image VARCHAR(255),
+    image_thumbnail VARCHAR(255),
     minimum_stock INT NOT NULL DEFAULT 0,
+    quarantine_stock INT NOT NULL DEFAULT 0,
+    requires_inspection BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMP NULL DEFAULT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (type_id) REFERENCES item_types(type_id) ON DELETE RESTRICT,
     FOREIGN KEY (unit_id) REFERENCES units(unit_id) ON DELETE RESTRICT
 );
 
+-- Tabel `barcode barang`
+CREATE TABLE item_barcodes (
+    barcode_id char(36) PRIMARY KEY,
+    item_id char(36) NOT NULL,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    symbology ENUM('ean13', 'code128', 'custom') NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE
+);
+
+-- Tabel `galeri gambar barang`
+CREATE TABLE item_images (
+    image_id char(36) PRIMARY KEY,
+    item_id char(36) NOT NULL,
+    url VARCHAR(255) NOT NULL,
+    medium_url VARCHAR(255) NOT NULL,
+    thumbnail_url VARCHAR(255) NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_item_images_item (item_id),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE
+);
+
+-- Tabel `antrian penghapusan file storage`
+CREATE TABLE pending_deletions (
+    deletion_id char(36) PRIMARY KEY,
+    object_path VARCHAR(512) NOT NULL,
+    delete_after TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_pending_deletions_due (delete_after)
+);
+
+-- Tabel `nilai atribut barang`
+CREATE TABLE item_attribute_values (
+    item_id char(36) NOT NULL,
+    attribute_id char(36) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, attribute_id),
+    INDEX idx_attribute_value (attribute_id, value),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    FOREIGN KEY (attribute_id) REFERENCES item_type_attributes(attribute_id) ON DELETE CASCADE
+);
+
+-- Tabel `komponen kit (bill of materials)`
+CREATE TABLE bom_components (
+    bom_component_id char(36) PRIMARY KEY,
+    kit_item_id char(36) NOT NULL,
+    component_item_id char(36) NOT NULL,
+    quantity INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_bom_kit_component (kit_item_id, component_item_id),
+    FOREIGN KEY (kit_item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    FOREIGN KEY (component_item_id) REFERENCES items(item_id) ON DELETE RESTRICT,
+    CHECK (quantity > 0)
+);
+
 -- Tabel `transaksi`
 CREATE TABLE transactions (
     transaction_id char(36) PRIMARY KEY,
@@ -51,22 +234,113 @@ CREATE TABLE transactions (
     date DATE NOT NULL,
     quantity INT NOT NULL,
     transaction_type ENUM('in', 'out') NOT NULL,
+    inspection_status ENUM('none', 'pending', 'inspected') NOT NULL DEFAULT 'none',
     description TEXT,
+    reference_type VARCHAR(50),
+    reference_id char(36),
+    user_id char(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_transactions_reference (reference_type, reference_id),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE RESTRICT,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+-- Tabel `lampiran transaksi`
+-- Lampiran dimiliki satu transaksi (transaction_id) atau satu dokumen
+-- (reference_id, misalnya batch impor)
+CREATE TABLE transaction_attachments (
+    attachment_id char(36) PRIMARY KEY,
+    transaction_id char(36),
+    reference_id char(36),
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    url VARCHAR(255) NOT NULL,
+    user_id char(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_attachments_transaction (transaction_id),
+    INDEX idx_attachments_reference (reference_id),
+    CHECK ((transaction_id IS NULL) <> (reference_id IS NULL)),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(transaction_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+-- Tabel `inspeksi barang masuk`
+CREATE TABLE inspections (
+    inspection_id char(36) PRIMARY KEY,
+    transaction_id char(36) NOT NULL,
+    item_id char(36) NOT NULL,
+    released_quantity INT NOT NULL DEFAULT 0,
+    rejected_quantity INT NOT NULL DEFAULT 0,
+    disposition ENUM('return', 'scrap'),
+    notes TEXT,
     user_id char(36) NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(transaction_id) ON DELETE CASCADE,
     FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE RESTRICT,
     FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
 );
 
+-- Tabel `riwayat perubahan transaksi`
+CREATE TABLE transaction_revisions (
+    revision_id char(36) PRIMARY KEY,
+    transaction_id char(36) NOT NULL,
+    old_values TEXT NOT NULL,
+    new_values TEXT NOT NULL,
+    reason TEXT,
+    user_id char(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_transaction_revisions_transaction (transaction_id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(transaction_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+-- Tabel `periode akuntansi tertutup`
+CREATE TABLE closed_periods (
+    period_id char(36) PRIMARY KEY,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status ENUM('closed', 'reopened') NOT NULL DEFAULT 'closed',
+    note TEXT,
+    closed_by char(36) NOT NULL,
+    reopened_by char(36),
+    reopen_reason TEXT,
+    reopened_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_closed_periods_range (status, start_date, end_date),
+    FOREIGN KEY (closed_by) REFERENCES users(user_id) ON DELETE RESTRICT,
+    FOREIGN KEY (reopened_by) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+-- Tabel `audit log`
+CREATE TABLE audit_logs (
+    audit_id char(36) PRIMARY KEY,
+    user_id char(36) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(100) NOT NULL,
+    entity_id char(36),
+    details TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_logs_entity (entity_type, entity_id),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
 DELIMITER $$
 
 -- Trigger untuk transaksi masuk
+-- Barang yang wajib inspeksi masuk ke stok karantina, bukan stok tersedia
 CREATE TRIGGER stock_in
 AFTER INSERT ON transactions
 FOR EACH ROW
 BEGIN
-    IF NEW.transaction_type = 'in' THEN
+    IF NEW.transaction_type = 'in' AND NEW.inspection_status = 'pending' THEN
+        UPDATE items
+        SET quarantine_stock = quarantine_stock + NEW.quantity
+        WHERE item_id = NEW.item_id;
+    ELSEIF NEW.transaction_type = 'in' THEN
         UPDATE items
         SET stock = stock + NEW.quantity
         WHERE item_id = NEW.item_id;
@@ -86,11 +360,24 @@ BEGIN
 END $$
 
 -- Trigger untuk menghapus transaksi
+-- Transaksi masuk yang sudah diinspeksi hanya mengurangi jumlah yang diterima
 CREATE TRIGGER delete_stock
 BEFORE DELETE ON transactions
 FOR EACH ROW
 BEGIN
-    IF OLD.transaction_type = 'in' THEN
+    IF OLD.transaction_type = 'in' AND OLD.inspection_status = 'pending' THEN
+        UPDATE items
+        SET quarantine_stock = GREATEST(quarantine_stock - OLD.quantity, 0)
+        WHERE item_id = OLD.item_id;
+    ELSEIF OLD.transaction_type = 'in' AND OLD.inspection_status = 'inspected' THEN
+        UPDATE items
+        SET stock = GREATEST(stock - (
+            SELECT COALESCE(SUM(released_quantity), 0)
+            FROM inspections
+            WHERE transaction_id = OLD.transaction_id
+        ), 0)
+        WHERE item_id = OLD.item_id;
+    ELSEIF OLD.transaction_type = 'in' THEN
         UPDATE items
         SET stock = GREATEST(stock - OLD.quantity, 0)
         WHERE item_id = OLD.item_id;
@@ -101,8 +388,120 @@ BEGIN
     END IF;
 END $$
 
+-- Trigger untuk perubahan transaksi
+-- Efek baris lama dibatalkan lalu efek baris baru diterapkan, termasuk
+-- perpindahan stok karantina ke stok tersedia setelah inspeksi
+CREATE TRIGGER update_stock
+AFTER UPDATE ON transactions
+FOR EACH ROW
+BEGIN
+    DECLARE released INT DEFAULT 0;
+
+    IF OLD.item_id <> NEW.item_id
+        OR OLD.quantity <> NEW.quantity
+        OR OLD.transaction_type <> NEW.transaction_type
+        OR OLD.inspection_status <> NEW.inspection_status THEN
+
+        SELECT COALESCE(SUM(released_quantity), 0) INTO released
+        FROM inspections
+        WHERE transaction_id = NEW.transaction_id;
+
+        -- Batalkan efek baris lama
+        IF OLD.transaction_type = 'in' AND OLD.inspection_status = 'pending' THEN
+            UPDATE items
+            SET quarantine_stock = GREATEST(quarantine_stock - OLD.quantity, 0)
+            WHERE item_id = OLD.item_id;
+        ELSEIF OLD.transaction_type = 'in' AND OLD.inspection_status = 'inspected' THEN
+            UPDATE items
+            SET stock = GREATEST(stock - released, 0)
+            WHERE item_id = OLD.item_id;
+        ELSEIF OLD.transaction_type = 'in' THEN
+            UPDATE items
+            SET stock = GREATEST(stock - OLD.quantity, 0)
+            WHERE item_id = OLD.item_id;
+        ELSEIF OLD.transaction_type = 'out' THEN
+            UPDATE items
+            SET stock = stock + OLD.quantity
+            WHERE item_id = OLD.item_id;
+        END IF;
+
+        -- Terapkan efek baris baru
+        IF NEW.transaction_type = 'in' AND NEW.inspection_status = 'pending' THEN
+            UPDATE items
+            SET quarantine_stock = quarantine_stock + NEW.quantity
+            WHERE item_id = NEW.item_id;
+        ELSEIF NEW.transaction_type = 'in' AND NEW.inspection_status = 'inspected' THEN
+            UPDATE items
+            SET stock = stock + released
+            WHERE item_id = NEW.item_id;
+        ELSEIF NEW.transaction_type = 'in' THEN
+            UPDATE items
+            SET stock = stock + NEW.quantity
+            WHERE item_id = NEW.item_id;
+        ELSEIF NEW.transaction_type = 'out' THEN
+            UPDATE items
+            SET stock = stock - NEW.quantity
+            WHERE item_id = NEW.item_id;
+        END IF;
+    END IF;
+END $$
+
 DELIMITER ;
 
+INSERT INTO roles (name, description, is_system)
+VALUES
+    ('admin', 'Administrator dengan seluruh izin', TRUE),
+    ('warehouse_admin', 'Admin gudang', TRUE),
+    ('warehouse_manager', 'Kepala gudang, hanya melihat data', TRUE),
+    ('service_account', 'Akun integrasi, izin diatur per API key', TRUE);
+
+INSERT INTO permissions (permission, description)
+VALUES
+    ('items.read', 'Melihat barang'),
+    ('items.write', 'Menambah, mengubah dan mengarsipkan barang'),
+    ('items.merge', 'Menggabungkan barang duplikat'),
+    ('master_data.read', 'Melihat jenis barang dan satuan'),
+    ('master_data.write', 'Mengubah jenis barang dan satuan'),
+    ('master_data.stock_policy', 'Mengubah kebijakan validasi stok jenis barang'),
+    ('transactions.read', 'Melihat transaksi dan lampirannya'),
+    ('transactions.write', 'Mencatat, mengubah dan menghapus transaksi'),
+    ('inspections.read', 'Melihat inspeksi barang masuk'),
+    ('inspections.write', 'Mencatat inspeksi barang masuk'),
+    ('periods.read', 'Melihat periode akuntansi'),
+    ('periods.manage', 'Menutup periode'),
+    ('periods.reopen', 'Membuka kembali periode yang sudah ditutup (khusus admin)'),
+    ('summary.read', 'Melihat ringkasan inventaris'),
+    ('reports.export', 'Mengunduh laporan'),
+    ('users.manage', 'Mengelola user'),
+    ('roles.manage', 'Mengelola role dan izin'),
+    ('api_keys.manage', 'Mengelola API key integrasi'),
+    ('audit_logs.read', 'Melihat audit log'),
+    ('stock.reconcile', 'Menjalankan rekonsiliasi stok');
+
+INSERT INTO role_permissions (role_name, permission)
+SELECT 'admin', permission FROM permissions;
+
+INSERT INTO role_permissions (role_name, permission)
+VALUES
+    ('warehouse_admin', 'items.read'),
+    ('warehouse_admin', 'items.write'),
+    ('warehouse_admin', 'master_data.read'),
+    ('warehouse_admin', 'master_data.write'),
+    ('warehouse_admin', 'transactions.read'),
+    ('warehouse_admin', 'transactions.write'),
+    ('warehouse_admin', 'inspections.read'),
+    ('warehouse_admin', 'inspections.write'),
+    ('warehouse_admin', 'periods.read'),
+    ('warehouse_admin', 'summary.read'),
+    ('warehouse_admin', 'reports.export'),
+    ('warehouse_manager', 'items.read'),
+    ('warehouse_manager', 'master_data.read'),
+    ('warehouse_manager', 'transactions.read'),
+    ('warehouse_manager', 'inspections.read'),
+    ('warehouse_manager', 'periods.read'),
+    ('warehouse_manager', 'summary.read'),
+    ('warehouse_manager', 'reports.export');
+
 INSERT INTO users (user_id,username, password, full_name, role)
 VALUES
     -- password: Admin1234